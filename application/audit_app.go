@@ -0,0 +1,107 @@
+package application
+
+import (
+	"encoding/base64"
+	"forum/domain/entity"
+	"forum/domain/repository"
+	"strconv"
+	"time"
+
+	"github.com/mailru/easyjson"
+)
+
+const defaultAuditLimit = 50
+const maxAuditLimit = 200
+
+type AuditApp struct {
+	a repository.AuditRepository
+	r repository.RoleRepository
+}
+
+func NewAuditApp(a repository.AuditRepository, r repository.RoleRepository) *AuditApp {
+	return &AuditApp{a: a, r: r}
+}
+
+type AuditAppInterface interface {
+	NewEntry(entry *entity.AuditEntry, before easyjson.Marshaler) (*entity.AuditEntry, error)
+	GetAuditLog(query *entity.AuditQuery, since string, until string, cursor string, actor string) (*entity.AuditLog, error)
+}
+
+// NewEntry fills Before with the target as it is now. The repository performing the action writes the entry
+// in the transaction of the action, together with After, so the action fails when its entry can not be written
+func (a *AuditApp) NewEntry(entry *entity.AuditEntry, before easyjson.Marshaler) (*entity.AuditEntry, error) {
+	if before != nil {
+		var err error
+		entry.Before, err = easyjson.Marshal(before)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return entry, nil
+}
+
+// GetAuditLog is for admins only; since and until are RFC 3339 and cursor continues after the last page
+func (a *AuditApp) GetAuditLog(query *entity.AuditQuery, since string, until string, cursor string, actor string) (*entity.AuditLog, error) {
+	role, err := a.r.GetRole(actor, "")
+	if err != nil {
+		return nil, err
+	}
+	if role != entity.RoleAdmin {
+		return nil, entity.PermissionDeniedError
+	}
+
+	if since != "" {
+		query.Since, err = time.Parse(time.RFC3339, since)
+		if err != nil {
+			return nil, entity.InvalidTimeRangeError
+		}
+	}
+	if until != "" {
+		query.Until, err = time.Parse(time.RFC3339, until)
+		if err != nil {
+			return nil, entity.InvalidTimeRangeError
+		}
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultAuditLimit
+	}
+	if query.Limit > maxAuditLimit {
+		query.Limit = maxAuditLimit
+	}
+
+	if cursor != "" {
+		query.AfterID, err = decodeAuditCursor(cursor)
+		if err != nil {
+			return nil, entity.InvalidCursorError
+		}
+	}
+
+	entries, err := a.a.GetAuditEntries(query)
+	if err != nil {
+		return nil, err
+	}
+
+	auditLog := &entity.AuditLog{Entries: entries}
+	if len(entries) == int(query.Limit) {
+		auditLog.Cursor = encodeAuditCursor(entries[len(entries)-1].ID)
+	}
+	return auditLog, nil
+}
+
+func encodeAuditCursor(id int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.Itoa(id)))
+}
+
+func decodeAuditCursor(cursor string) (int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, err
+	}
+
+	id, err := strconv.Atoi(string(raw))
+	if err != nil || id <= 0 {
+		return 0, entity.InvalidCursorError
+	}
+	return id, nil
+}
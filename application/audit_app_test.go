@@ -0,0 +1,25 @@
+package application
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestAuditCursorRoundTrip(t *testing.T) {
+	id, err := decodeAuditCursor(encodeAuditCursor(1234))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if id != 1234 {
+		t.Errorf("got id %v, want 1234", id)
+	}
+}
+
+func TestAuditCursorRejectsForeignInput(t *testing.T) {
+	for _, raw := range []string{"", "0", "-5", "12a"} {
+		_, err := decodeAuditCursor(base64.RawURLEncoding.EncodeToString([]byte(raw)))
+		if err == nil {
+			t.Errorf("cursor %q was accepted", raw)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package application
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"forum/domain/entity"
+	"forum/domain/repository"
+	"golang.org/x/image/draw"
+	"image"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+	"net/http"
+	"strings"
+)
+
+const MaxAvatarSize = 2 << 20
+const maxAvatarDimension = 4096
+const avatarDisplaySize = 256
+const avatarQuality = 85
+
+// avatarSizes are the square sizes every uploaded avatar is resized to,
+// the user profile points to the avatarDisplaySize one
+var avatarSizes = []int{64, 128, avatarDisplaySize}
+
+var avatarContentTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/gif":  true,
+}
+
+type AvatarApp struct {
+	us      repository.UserRepository
+	storage repository.AvatarStorage
+}
+
+func NewAvatarApp(us repository.UserRepository, storage repository.AvatarStorage) *AvatarApp {
+	return &AvatarApp{us: us, storage: storage}
+}
+
+type AvatarAppInterface interface {
+	UpdateAvatar(nickname string, data []byte) (*entity.User, error)
+}
+
+func (a *AvatarApp) UpdateAvatar(nickname string, data []byte) (*entity.User, error) {
+	if len(data) > MaxAvatarSize {
+		return nil, entity.AvatarTooLargeError
+	}
+
+	if !avatarContentTypes[http.DetectContentType(data)] {
+		return nil, entity.UnsupportedAvatarTypeError
+	}
+
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, entity.UnsupportedAvatarTypeError
+	}
+	if config.Width > maxAvatarDimension || config.Height > maxAvatarDimension {
+		return nil, entity.AvatarTooLargeError
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, entity.UnsupportedAvatarTypeError
+	}
+
+	user, err := a.us.GetUserByNickname(nickname)
+	if err != nil {
+		return nil, err
+	}
+
+	suffix := make([]byte, 8)
+	_, err = rand.Read(suffix)
+	if err != nil {
+		return nil, err
+	}
+	key := fmt.Sprintf("%s-%s", strings.ToLower(user.Nickname), hex.EncodeToString(suffix))
+
+	var avatar string
+	for _, size := range avatarSizes {
+		buf := &bytes.Buffer{}
+		err = jpeg.Encode(buf, resizeSquare(img, size), &jpeg.Options{Quality: avatarQuality})
+		if err != nil {
+			return nil, err
+		}
+
+		path, err := a.storage.SaveAvatar(avatarName(key, size), buf.Bytes())
+		if err != nil {
+			return nil, err
+		}
+
+		if size == avatarDisplaySize {
+			avatar = path
+		}
+	}
+
+	err = a.us.UpdateAvatar(user.Nickname, avatar)
+	if err != nil {
+		return nil, err
+	}
+
+	a.deleteAvatar(user.Avatar)
+	user.Avatar = avatar
+	return user, nil
+}
+
+// deleteAvatar removes every size of a previously uploaded avatar, best effort
+func (a *AvatarApp) deleteAvatar(avatar string) {
+	displaySuffix := avatarName("", avatarDisplaySize)
+	if avatar == entity.AvatarDefaultPath || !strings.HasSuffix(avatar, displaySuffix) {
+		return
+	}
+
+	base := strings.TrimSuffix(avatar, displaySuffix)
+	for _, size := range avatarSizes {
+		_ = a.storage.DeleteAvatar(base + avatarName("", size))
+	}
+}
+
+func avatarName(key string, size int) string {
+	return fmt.Sprintf("%s_%d.jpg", key, size)
+}
+
+// resizeSquare center-crops img to a square and scales it to size x size.
+// Transparent areas are flattened onto white since the result is a jpeg.
+func resizeSquare(img image.Image, size int) *image.RGBA {
+	bounds := img.Bounds()
+	side := bounds.Dx()
+	if bounds.Dy() < side {
+		side = bounds.Dy()
+	}
+	offset := image.Pt(bounds.Min.X+(bounds.Dx()-side)/2, bounds.Min.Y+(bounds.Dy()-side)/2)
+
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), img, image.Rectangle{Min: offset, Max: offset.Add(image.Pt(side, side))}, draw.Over, nil)
+	return dst
+}
@@ -0,0 +1,77 @@
+package application
+
+import (
+	"forum/domain/entity"
+	"forum/domain/repository"
+	"strconv"
+)
+
+type BanApp struct {
+	b         repository.BanRepository
+	policyApp PolicyAppInterface
+	auditApp  AuditAppInterface
+}
+
+func NewBanApp(b repository.BanRepository, policyApp PolicyAppInterface, auditApp AuditAppInterface) *BanApp {
+	return &BanApp{b: b, policyApp: policyApp, auditApp: auditApp}
+}
+
+type BanAppInterface interface {
+	GetBans(activeOnly bool, actor string) ([]entity.Ban, error)
+	CreateBan(ban *entity.Ban, actor string) error
+	LiftBan(banID int, actor string) (*entity.Ban, error)
+}
+
+func (b *BanApp) GetBans(activeOnly bool, actor string) ([]entity.Ban, error) {
+	err := b.policyApp.CheckIsAdmin(actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.b.GetBans(activeOnly)
+}
+
+// CreateBan bans the user globally, or mutes them when ban.Forum is set
+func (b *BanApp) CreateBan(ban *entity.Ban, actor string) error {
+	err := b.policyApp.CheckIsAdmin(actor)
+	if err != nil {
+		return err
+	}
+
+	if ban.Nickname == "" {
+		return entity.DataError
+	}
+	ban.IssuedBy = actor
+	ban.Lifted = nil
+	ban.LiftedBy = ""
+	audit, err := b.auditApp.NewEntry(&entity.AuditEntry{
+		Actor:      actor,
+		Action:     entity.AuditBanCreate,
+		TargetType: entity.AuditTargetBan,
+		Forum:      ban.Forum,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	return b.b.CreateBan(ban, audit)
+}
+
+func (b *BanApp) LiftBan(banID int, actor string) (*entity.Ban, error) {
+	err := b.policyApp.CheckIsAdmin(actor)
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := b.auditApp.NewEntry(&entity.AuditEntry{
+		Actor:      actor,
+		Action:     entity.AuditBanLift,
+		TargetType: entity.AuditTargetBan,
+		Target:     strconv.Itoa(banID),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return b.b.LiftBan(banID, actor, audit)
+}
@@ -0,0 +1,147 @@
+package application
+
+import (
+	"forum/domain/entity"
+	"forum/domain/repository"
+	"strings"
+)
+
+const defaultConversationLimit = 50
+
+type ConversationApp struct {
+	c       repository.ConversationRepository
+	userApp UserAppInterface
+}
+
+func NewConversationApp(c repository.ConversationRepository, userApp UserAppInterface) *ConversationApp {
+	return &ConversationApp{c: c, userApp: userApp}
+}
+
+type ConversationAppInterface interface {
+	StartConversation(input *entity.ConversationInput, actor string) (*entity.Conversation, error)
+	GetConversations(actor string, limit int32, since int) ([]entity.Conversation, error)
+	SendMessage(conversationID int, input *entity.PrivateMessageInput, actor string) (*entity.PrivateMessage, error)
+	GetMessages(conversationID int, limit int32, since int, desc bool, actor string) ([]entity.PrivateMessage, error)
+	BlockUser(nickname string, actor string) error
+	UnblockUser(nickname string, actor string) error
+	GetBlockedUsers(actor string) ([]string, error)
+}
+
+func (c *ConversationApp) checkNotBlocked(nickname string, with string) error {
+	blocked, err := c.c.IsBlocked(nickname, with)
+	if err != nil {
+		return err
+	}
+	if blocked {
+		return entity.UserBlockedError
+	}
+
+	return nil
+}
+
+// getMember loads the conversation for one of its members, to anybody else it does not exist
+func (c *ConversationApp) getMember(conversationID int, actor string) (*entity.Conversation, error) {
+	conversation, err := c.c.GetConversation(conversationID)
+	if err != nil {
+		return nil, entity.ConversationNotFoundError
+	}
+
+	for _, member := range conversation.Members {
+		if strings.EqualFold(member, actor) {
+			return conversation, nil
+		}
+	}
+	return nil, entity.ConversationNotFoundError
+}
+
+// StartConversation reopens the existing conversation with the user if there is one and posts the optional first message
+func (c *ConversationApp) StartConversation(input *entity.ConversationInput, actor string) (*entity.Conversation, error) {
+	with, err := resolveOtherUser(c.userApp, input.With, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	err = c.checkNotBlocked(actor, with)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := c.c.CreateConversation(actor, with)
+	if err != nil {
+		return nil, err
+	}
+
+	if input.Message != "" {
+		err = c.c.AddMessage(&entity.PrivateMessage{Conversation: id, Author: actor, Message: input.Message})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return c.c.GetConversation(id)
+}
+
+func (c *ConversationApp) GetConversations(actor string, limit int32, since int) ([]entity.Conversation, error) {
+	if limit <= 0 {
+		limit = defaultConversationLimit
+	}
+	return c.c.GetConversations(actor, limit, since)
+}
+
+func (c *ConversationApp) SendMessage(conversationID int, input *entity.PrivateMessageInput, actor string) (*entity.PrivateMessage, error) {
+	if input.Message == "" {
+		return nil, entity.DataError
+	}
+
+	conversation, err := c.getMember(conversationID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, member := range conversation.Members {
+		if strings.EqualFold(member, actor) {
+			continue
+		}
+		err = c.checkNotBlocked(actor, member)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	message := &entity.PrivateMessage{Conversation: conversation.ID, Author: actor, Message: input.Message}
+	return message, c.c.AddMessage(message)
+}
+
+func (c *ConversationApp) GetMessages(conversationID int, limit int32, since int, desc bool, actor string) ([]entity.PrivateMessage, error) {
+	conversation, err := c.getMember(conversationID, actor)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultConversationLimit
+	}
+	return c.c.GetMessages(conversation.ID, limit, since, desc)
+}
+
+func (c *ConversationApp) BlockUser(nickname string, actor string) error {
+	nickname, err := resolveOtherUser(c.userApp, nickname, actor)
+	if err != nil {
+		return err
+	}
+
+	return c.c.BlockUser(actor, nickname)
+}
+
+func (c *ConversationApp) UnblockUser(nickname string, actor string) error {
+	nickname, err := resolveOtherUser(c.userApp, nickname, actor)
+	if err != nil {
+		return err
+	}
+
+	return c.c.UnblockUser(actor, nickname)
+}
+
+func (c *ConversationApp) GetBlockedUsers(actor string) ([]string, error) {
+	return c.c.GetBlockedUsers(actor)
+}
@@ -3,32 +3,75 @@ package application
 import (
 	"forum/domain/entity"
 	"forum/domain/repository"
+	"strings"
 )
 
 type ForumApp struct {
-	f repository.ForumRepository
+	f         repository.ForumRepository
+	policyApp PolicyAppInterface
+	auditApp  AuditAppInterface
 }
 
-func NewForumApp(f repository.ForumRepository) *ForumApp {
-	return &ForumApp{f: f}
+func NewForumApp(f repository.ForumRepository, policyApp PolicyAppInterface, auditApp AuditAppInterface) *ForumApp {
+	return &ForumApp{f: f, policyApp: policyApp, auditApp: auditApp}
 }
 
 type ForumAppInterface interface {
 	CreateForum(forumInput *entity.Forum) error
 	GetForumDetails(slug string) (*entity.Forum, error)
-	GetForumUsers(slug string, limit int32, since string, desc bool) ([]entity.User, error)
+	GetForumUsers(slug string, limit int32, since string, desc bool, sort string) ([]entity.User, error)
 	CheckForumCase(slug string) (string, error)
+	DeleteForum(slug string, nickname string) error
+	GetForumTree() ([]entity.Forum, error)
 }
 
+// CreateForum nests the forum under Parent when it is set, the parent has to exist already
 func (f *ForumApp) CreateForum(forumInput *entity.Forum) error {
+	if forumInput.Parent != "" {
+		parent, err := f.f.CheckForum(forumInput.Parent)
+		if err != nil {
+			return entity.ForumNotExistError
+		}
+		forumInput.Parent = parent
+	}
+
 	return f.f.CreateForum(forumInput)
 }
 
+// GetForumTree nests every forum under its parent, the roots are usually categories
+func (f *ForumApp) GetForumTree() ([]entity.Forum, error) {
+	forums, err := f.f.GetForums()
+	if err != nil {
+		return nil, err
+	}
+
+	children := make(map[string][]entity.Forum)
+	for _, forum := range forums {
+		children[strings.ToLower(forum.Parent)] = append(children[strings.ToLower(forum.Parent)], forum)
+	}
+
+	var build func(parent string) []entity.Forum
+	build = func(parent string) []entity.Forum {
+		nodes := children[strings.ToLower(parent)]
+		for idx := range nodes {
+			nodes[idx].SubForums = build(nodes[idx].Slug)
+		}
+		return nodes
+	}
+
+	tree := build("")
+	if tree == nil {
+		tree = make([]entity.Forum, 0)
+	}
+	return tree, nil
+}
+
 func (f *ForumApp) GetForumDetails(slug string) (*entity.Forum, error) {
 	return f.f.GetForumDetails(slug)
 }
 
-func (f *ForumApp) GetForumUsers(slug string, limit int32, since string, desc bool) ([]entity.User, error) {
+// GetForumUsers orders by nickname, or by karma when sort is "karma"
+func (f *ForumApp) GetForumUsers(slug string, limit int32, since string, desc bool, sort string) ([]entity.User, error) {
 	order := "ASC"
 	var compare string
 	if desc {
@@ -37,10 +80,40 @@ func (f *ForumApp) GetForumUsers(slug string, limit int32, since string, desc bo
 	} else {
 		compare = ">"
 	}
-	return f.f.GetForumUsers(slug, limit, since, order, compare)
+
+	switch sort {
+	case "karma":
+		return f.f.GetForumUsersByKarma(slug, limit, since, order, compare)
+	default:
+		return f.f.GetForumUsers(slug, limit, since, order, compare)
+	}
 }
 
 func (f *ForumApp) CheckForumCase(slug string) (string, error) {
 	return f.f.CheckForum(slug)
 }
 
+func (f *ForumApp) DeleteForum(slug string, nickname string) error {
+	err := f.policyApp.CheckCanManageForum(nickname, slug)
+	if err != nil {
+		return err
+	}
+
+	forum, err := f.f.GetForumDetails(slug)
+	if err != nil {
+		return err
+	}
+
+	audit, err := f.auditApp.NewEntry(&entity.AuditEntry{
+		Actor:      nickname,
+		Action:     entity.AuditForumDelete,
+		TargetType: entity.AuditTargetForum,
+		Target:     forum.Slug,
+		Forum:      forum.Slug,
+	}, forum)
+	if err != nil {
+		return err
+	}
+
+	return f.f.DeleteForum(slug, audit)
+}
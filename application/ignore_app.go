@@ -0,0 +1,72 @@
+package application
+
+import (
+	"forum/domain/entity"
+	"forum/domain/repository"
+	"strings"
+)
+
+type IgnoreApp struct {
+	i       repository.IgnoreRepository
+	userApp UserAppInterface
+}
+
+func NewIgnoreApp(i repository.IgnoreRepository, userApp UserAppInterface) *IgnoreApp {
+	return &IgnoreApp{i: i, userApp: userApp}
+}
+
+type IgnoreAppInterface interface {
+	IgnoreUser(nickname string, actor string) error
+	UnignoreUser(nickname string, actor string) error
+	GetIgnoredUsers(actor string) ([]string, error)
+	CollapseIgnoredPosts(posts []entity.Post, viewer string) error
+}
+
+func (i *IgnoreApp) IgnoreUser(nickname string, actor string) error {
+	nickname, err := resolveOtherUser(i.userApp, nickname, actor)
+	if err != nil {
+		return err
+	}
+
+	return i.i.IgnoreUser(actor, nickname)
+}
+
+func (i *IgnoreApp) UnignoreUser(nickname string, actor string) error {
+	nickname, err := resolveOtherUser(i.userApp, nickname, actor)
+	if err != nil {
+		return err
+	}
+
+	return i.i.UnignoreUser(actor, nickname)
+}
+
+func (i *IgnoreApp) GetIgnoredUsers(actor string) ([]string, error) {
+	return i.i.GetIgnoredUsers(actor)
+}
+
+// CollapseIgnoredPosts turns posts of users the viewer ignores into placeholders in place.
+// They keep their id and parent, so replies from other users still hang off the right branch
+func (i *IgnoreApp) CollapseIgnoredPosts(posts []entity.Post, viewer string) error {
+	ignored, err := i.i.GetIgnoredUsers(viewer)
+	if err != nil {
+		return err
+	}
+	if len(ignored) == 0 {
+		return nil
+	}
+
+	authors := make(map[string]bool, len(ignored))
+	for _, nickname := range ignored {
+		authors[strings.ToLower(nickname)] = true
+	}
+
+	for idx := range posts {
+		if !authors[strings.ToLower(posts[idx].Author)] {
+			continue
+		}
+		posts[idx].IsIgnored = true
+		posts[idx].Message = entity.IgnoredPostMessage
+		posts[idx].Reactions = nil
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+package application
+
+import "forum/domain/repository"
+
+// liveEventBuffer is how many events a live connection may lag behind before it is dropped
+const liveEventBuffer = 64
+
+type LiveApp struct {
+	listener repository.EventListener
+}
+
+func NewLiveApp(listener repository.EventListener) *LiveApp {
+	return &LiveApp{listener: listener}
+}
+
+type LiveAppInterface interface {
+	Subscribe() repository.EventSubscription
+}
+
+func (l *LiveApp) Subscribe() repository.EventSubscription {
+	return l.listener.Subscribe(liveEventBuffer)
+}
@@ -0,0 +1,39 @@
+package application
+
+import (
+	"forum/domain/entity"
+	"forum/domain/repository"
+)
+
+type NotificationApp struct {
+	n repository.NotificationRepository
+}
+
+func NewNotificationApp(n repository.NotificationRepository) *NotificationApp {
+	return &NotificationApp{n: n}
+}
+
+type NotificationAppInterface interface {
+	GetNotifications(nickname string, limit int32, since int, desc bool, unread bool) ([]entity.Notification, error)
+	GetNotification(nickname string, ID int) (*entity.Notification, error)
+	MarkRead(nickname string, input *entity.NotificationReadInput) error
+}
+
+func (n *NotificationApp) GetNotifications(nickname string, limit int32, since int, desc bool, unread bool) ([]entity.Notification, error) {
+	return n.n.GetNotifications(nickname, limit, since, desc, unread)
+}
+
+func (n *NotificationApp) GetNotification(nickname string, ID int) (*entity.Notification, error) {
+	return n.n.GetNotification(nickname, ID)
+}
+
+func (n *NotificationApp) MarkRead(nickname string, input *entity.NotificationReadInput) error {
+	switch input.Type {
+	case entity.AllNotificationsTypeKey:
+		return n.n.MarkAllNotificationsRead(nickname)
+	case entity.OneNotificationTypeKey:
+		return n.n.MarkNotificationRead(nickname, input.ID)
+	default:
+		return entity.UnknownNotificationTypeError
+	}
+}
@@ -0,0 +1,176 @@
+package application
+
+import (
+	"errors"
+	"forum/domain/entity"
+	"forum/domain/repository"
+	"strings"
+)
+
+// PolicyApp decides who may mutate what. Checks return PermissionDeniedError on refusal,
+// CheckCanParticipate and CheckCanEdit return UserBannedError or UserMutedError for banned and muted users
+type PolicyApp struct {
+	r        repository.RoleRepository
+	b        repository.BanRepository
+	f        repository.ForumRepository
+	auditApp AuditAppInterface
+}
+
+func NewPolicyApp(r repository.RoleRepository, b repository.BanRepository, f repository.ForumRepository, auditApp AuditAppInterface) *PolicyApp {
+	return &PolicyApp{r: r, b: b, f: f, auditApp: auditApp}
+}
+
+type PolicyAppInterface interface {
+	GetRole(nickname string, forum string) (string, error)
+	CheckCanEdit(nickname string, forum string, author string) error
+	CheckCanModerate(nickname string, forum string) error
+	CheckCanManageForum(nickname string, forum string) error
+	CheckIsAdmin(nickname string) error
+	CheckCanParticipate(nickname string, forum string) error
+	GetForumModerators(forum string) ([]string, error)
+	GrantModerator(forum string, nickname string, actor string) ([]string, error)
+	RevokeModerator(forum string, nickname string, actor string) ([]string, error)
+}
+
+func (p *PolicyApp) GetRole(nickname string, forum string) (string, error) {
+	return p.r.GetRole(nickname, forum)
+}
+
+// CheckCanEdit lets the author change their own content, and moderators of the forum anything in it,
+// unless they are banned or muted in the forum
+func (p *PolicyApp) CheckCanEdit(nickname string, forum string, author string) error {
+	err := p.CheckCanParticipate(nickname, forum)
+	if err != nil {
+		return err
+	}
+
+	role, err := p.r.GetRole(nickname, forum)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case role == entity.RoleAdmin || role == entity.RoleModerator:
+		return nil
+	case strings.EqualFold(nickname, author):
+		return nil
+	}
+	return entity.PermissionDeniedError
+}
+
+func (p *PolicyApp) CheckCanModerate(nickname string, forum string) error {
+	role, err := p.r.GetRole(nickname, forum)
+	if err != nil {
+		return err
+	}
+
+	if role != entity.RoleAdmin && role != entity.RoleModerator {
+		return entity.PermissionDeniedError
+	}
+	return nil
+}
+
+// CheckCanManageForum is reserved to admins and the forum creator: deleting it and appointing moderators
+func (p *PolicyApp) CheckCanManageForum(nickname string, forum string) error {
+	forumDetails, err := p.f.GetForumDetails(forum)
+	if err != nil {
+		return err
+	}
+
+	role, err := p.r.GetRole(nickname, forum)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case role == entity.RoleBanned:
+		return entity.PermissionDeniedError
+	case role == entity.RoleAdmin:
+		return nil
+	case strings.EqualFold(nickname, forumDetails.User):
+		return nil
+	}
+	return entity.PermissionDeniedError
+}
+
+func (p *PolicyApp) CheckIsAdmin(nickname string) error {
+	role, err := p.r.GetRole(nickname, "")
+	if err != nil {
+		return err
+	}
+
+	if role != entity.RoleAdmin {
+		return entity.PermissionDeniedError
+	}
+	return nil
+}
+
+// CheckCanParticipate refuses new threads, posts and votes from banned users and users muted in the forum
+func (p *PolicyApp) CheckCanParticipate(nickname string, forum string) error {
+	ban, err := p.b.GetActiveBan(nickname, forum)
+	if errors.Is(err, entity.BanNotFoundError) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if ban.Forum == "" {
+		return entity.UserBannedError
+	}
+	return entity.UserMutedError
+}
+
+func (p *PolicyApp) GetForumModerators(forum string) ([]string, error) {
+	return p.r.GetForumModerators(forum)
+}
+
+func (p *PolicyApp) GrantModerator(forum string, nickname string, actor string) ([]string, error) {
+	err := p.CheckCanManageForum(actor, forum)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := p.r.GetForumModerators(forum)
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := p.auditApp.NewEntry(&entity.AuditEntry{
+		Actor:      actor,
+		Action:     entity.AuditModeratorGrant,
+		TargetType: entity.AuditTargetUser,
+		Target:     nickname,
+		Forum:      forum,
+	}, &entity.ForumModerators{Forum: forum, Moderators: before})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.r.AddForumModerator(forum, nickname, audit)
+}
+
+func (p *PolicyApp) RevokeModerator(forum string, nickname string, actor string) ([]string, error) {
+	err := p.CheckCanManageForum(actor, forum)
+	if err != nil {
+		return nil, err
+	}
+
+	before, err := p.r.GetForumModerators(forum)
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := p.auditApp.NewEntry(&entity.AuditEntry{
+		Actor:      actor,
+		Action:     entity.AuditModeratorRevoke,
+		TargetType: entity.AuditTargetUser,
+		Target:     nickname,
+		Forum:      forum,
+	}, &entity.ForumModerators{Forum: forum, Moderators: before})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.r.RemoveForumModerator(forum, nickname, audit)
+}
@@ -3,19 +3,47 @@ package application
 import (
 	"forum/domain/entity"
 	"forum/domain/repository"
+	"strconv"
+	"strings"
 )
 
 type PostApp struct {
-	p repository.PostRepository
+	p         repository.PostRepository
+	threadApp ThreadAppInterface
+	policyApp PolicyAppInterface
+	auditApp  AuditAppInterface
+	reactions map[string]bool
 }
 
-func NewPostApp(p repository.PostRepository) *PostApp {
-	return &PostApp{p: p}
+// NewPostApp accepts only the given reactions on posts, or entity.DefaultPostReactions when none are given
+func NewPostApp(
+	p repository.PostRepository,
+	threadApp ThreadAppInterface,
+	policyApp PolicyAppInterface,
+	auditApp AuditAppInterface,
+	reactions []string,
+) *PostApp {
+	if len(reactions) == 0 {
+		reactions = entity.DefaultPostReactions
+	}
+
+	allowed := make(map[string]bool, len(reactions))
+	for _, reaction := range reactions {
+		allowed[strings.TrimSpace(reaction)] = true
+	}
+	return &PostApp{p: p, threadApp: threadApp, policyApp: policyApp, auditApp: auditApp, reactions: allowed}
 }
 
 type PostAppInterface interface {
 	GetPostDetails(postID int) (*entity.Post, error)
 	ChangePostMessage(post *entity.Post) (*entity.Post, error)
+	DeletePost(postID int, nickname string) error
+	RestorePost(postID int, nickname string) (*entity.Post, error)
+	GetPostRevisions(postID int) ([]entity.PostRevision, error)
+	GetPostHistory(postID int, from int, to int) (*entity.PostHistory, error)
+	AddReaction(postID int, reaction string, nickname string) (*entity.Post, error)
+	RemoveReaction(postID int, reaction string, nickname string) (*entity.Post, error)
+	VoteForPost(vote *entity.Vote) (*entity.Post, error)
 }
 
 func (p *PostApp) GetPostDetails(postID int) (*entity.Post, error) {
@@ -28,8 +56,343 @@ func (p *PostApp) ChangePostMessage(post *entity.Post) (*entity.Post, error) {
 		return nil, err
 	}
 
+	if previousPost.IsDeleted {
+		return nil, entity.PostNotFoundError
+	}
+
+	err = p.policyApp.CheckCanEdit(post.Author, previousPost.Forum, previousPost.Author)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.threadApp.CheckThreadWritable(previousPost.Thread)
+	if err != nil {
+		return nil, err
+	}
+
 	if post.Message == previousPost.Message {
 		return previousPost, nil
 	}
-	return p.p.ChangePostMessage(post)
+
+	// authors editing their own posts are covered by the revision history
+	var audit *entity.AuditEntry
+	if !strings.EqualFold(post.Author, previousPost.Author) {
+		audit, err = p.auditApp.NewEntry(&entity.AuditEntry{
+			Actor:      post.Author,
+			Action:     entity.AuditPostEdit,
+			TargetType: entity.AuditTargetPost,
+			Target:     strconv.Itoa(previousPost.ID),
+			Forum:      previousPost.Forum,
+		}, previousPost)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return p.p.ChangePostMessage(post, audit)
+}
+
+// DeletePost tombstones the post; its author and moderators of the forum may do that
+func (p *PostApp) DeletePost(postID int, nickname string) error {
+	post, err := p.GetPostDetails(postID)
+	if err != nil {
+		return err
+	}
+
+	err = p.policyApp.CheckCanEdit(nickname, post.Forum, post.Author)
+	if err != nil {
+		return err
+	}
+
+	err = p.threadApp.CheckThreadWritable(post.Thread)
+	if err != nil {
+		return err
+	}
+
+	if post.IsDeleted {
+		return nil
+	}
+
+	audit, err := p.auditApp.NewEntry(&entity.AuditEntry{
+		Actor:      nickname,
+		Action:     entity.AuditPostDelete,
+		TargetType: entity.AuditTargetPost,
+		Target:     strconv.Itoa(post.ID),
+		Forum:      post.Forum,
+	}, post)
+	if err != nil {
+		return err
+	}
+
+	return p.p.DeletePost(postID, audit)
+}
+
+func (p *PostApp) RestorePost(postID int, nickname string) (*entity.Post, error) {
+	err := p.policyApp.CheckIsAdmin(nickname)
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := p.auditApp.NewEntry(&entity.AuditEntry{
+		Actor:      nickname,
+		Action:     entity.AuditPostRestore,
+		TargetType: entity.AuditTargetPost,
+		Target:     strconv.Itoa(postID),
+	}, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.p.RestorePost(postID, audit)
+}
+
+// GetPostRevisions lists revisions oldest first; a never edited post has its current message as the only revision
+func (p *PostApp) GetPostRevisions(postID int) ([]entity.PostRevision, error) {
+	post, err := p.GetPostDetails(postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.IsDeleted {
+		return nil, entity.PostNotFoundError
+	}
+
+	revisions, err := p.p.GetPostRevisions(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(revisions) == 0 {
+		revisions = append(revisions, entity.PostRevision{
+			Revision: 1,
+			Editor:   post.Author,
+			Message:  post.Message,
+			Created:  post.Created,
+		})
+	}
+	return revisions, nil
+}
+
+// GetPostHistory returns the revisions and, when from or to is set, a line diff between them.
+// A missing to means the latest revision, a missing from means the one right before to
+func (p *PostApp) GetPostHistory(postID int, from int, to int) (*entity.PostHistory, error) {
+	revisions, err := p.GetPostRevisions(postID)
+	if err != nil {
+		return nil, err
+	}
+
+	history := &entity.PostHistory{
+		Post:      postID,
+		Revisions: revisions,
+	}
+	if from == 0 && to == 0 {
+		return history, nil
+	}
+
+	if to == 0 {
+		to = revisions[len(revisions)-1].Revision
+	}
+	if from == 0 {
+		from = to - 1
+		if from < 1 {
+			from = 1
+		}
+	}
+	if from < 1 || from > len(revisions) || to < 1 || to > len(revisions) {
+		return nil, entity.RevisionNotFoundError
+	}
+
+	history.From = from
+	history.To = to
+	history.Diff = diffLines(revisions[from-1].Message, revisions[to-1].Message)
+	return history, nil
+}
+
+// maxDiffLinePairs bounds the comparisons of one diff, history is public and messages have no size limit
+const maxDiffLinePairs = 4000000
+
+// diffLines is a longest-common-subsequence diff over the lines of two messages. It runs in linear space
+// (Hirschberg); when the lines left after trimming the common head and tail would take more than
+// maxDiffLinePairs comparisons, they are reported as deleted and inserted as a whole
+func diffLines(oldText string, newText string) []entity.DiffLine {
+	oldLines := strings.Split(oldText, "\n")
+	newLines := strings.Split(newText, "\n")
+	diff := make([]entity.DiffLine, 0, len(oldLines)+len(newLines))
+
+	head := 0
+	for head < len(oldLines) && head < len(newLines) && oldLines[head] == newLines[head] {
+		diff = append(diff, entity.DiffLine{Op: entity.DiffEqual, Text: oldLines[head]})
+		head++
+	}
+	tail := 0
+	for tail < len(oldLines)-head && tail < len(newLines)-head &&
+		oldLines[len(oldLines)-1-tail] == newLines[len(newLines)-1-tail] {
+		tail++
+	}
+
+	oldRest, newRest := oldLines[head:len(oldLines)-tail], newLines[head:len(newLines)-tail]
+	if len(oldRest)*len(newRest) > maxDiffLinePairs {
+		diff = appendDiffLines(diff, entity.DiffDelete, oldRest)
+		diff = appendDiffLines(diff, entity.DiffInsert, newRest)
+	} else {
+		diff = diffLineRange(diff, oldRest, newRest)
+	}
+
+	for _, line := range oldLines[len(oldLines)-tail:] {
+		diff = append(diff, entity.DiffLine{Op: entity.DiffEqual, Text: line})
+	}
+	return diff
+}
+
+// diffLineRange splits oldLines in half and newLines where the longest common subsequence crosses that half
+func diffLineRange(diff []entity.DiffLine, oldLines []string, newLines []string) []entity.DiffLine {
+	switch {
+	case len(oldLines) == 0:
+		return appendDiffLines(diff, entity.DiffInsert, newLines)
+	case len(newLines) == 0:
+		return appendDiffLines(diff, entity.DiffDelete, oldLines)
+	case len(oldLines) == 1:
+		for j, line := range newLines {
+			if line == oldLines[0] {
+				diff = appendDiffLines(diff, entity.DiffInsert, newLines[:j])
+				diff = append(diff, entity.DiffLine{Op: entity.DiffEqual, Text: line})
+				return appendDiffLines(diff, entity.DiffInsert, newLines[j+1:])
+			}
+		}
+		diff = append(diff, entity.DiffLine{Op: entity.DiffDelete, Text: oldLines[0]})
+		return appendDiffLines(diff, entity.DiffInsert, newLines)
+	}
+
+	half := len(oldLines) / 2
+	forward := commonLengths(oldLines[:half], newLines, false)
+	backward := commonLengths(oldLines[half:], newLines, true)
+
+	split, best := 0, -1
+	for j := 0; j <= len(newLines); j++ {
+		if forward[j]+backward[j] > best {
+			split, best = j, forward[j]+backward[j]
+		}
+	}
+
+	diff = diffLineRange(diff, oldLines[:half], newLines[:split])
+	return diffLineRange(diff, oldLines[half:], newLines[split:])
+}
+
+// commonLengths returns for every j the length of the longest common subsequence of oldLines
+// and newLines[:j], or newLines[j:] when reverse is set, keeping only one row of the table
+func commonLengths(oldLines []string, newLines []string, reverse bool) []int {
+	line := func(lines []string, idx int) string {
+		if reverse {
+			return lines[len(lines)-1-idx]
+		}
+		return lines[idx]
+	}
+
+	prev := make([]int, len(newLines)+1)
+	cur := make([]int, len(newLines)+1)
+	for i := range oldLines {
+		for j := range newLines {
+			switch {
+			case line(oldLines, i) == line(newLines, j):
+				cur[j+1] = prev[j] + 1
+			case prev[j+1] >= cur[j]:
+				cur[j+1] = prev[j+1]
+			default:
+				cur[j+1] = cur[j]
+			}
+		}
+		prev, cur = cur, prev
+	}
+
+	if reverse {
+		for l, r := 0, len(prev)-1; l < r; l, r = l+1, r-1 {
+			prev[l], prev[r] = prev[r], prev[l]
+		}
+	}
+	return prev
+}
+
+func appendDiffLines(diff []entity.DiffLine, op string, lines []string) []entity.DiffLine {
+	for _, line := range lines {
+		diff = append(diff, entity.DiffLine{Op: op, Text: line})
+	}
+	return diff
+}
+
+// checkReactionTarget returns the post a reaction may be added to or removed from
+func (p *PostApp) checkReactionTarget(postID int, reaction string) (*entity.Post, error) {
+	if !p.reactions[reaction] {
+		return nil, entity.UnknownReactionError
+	}
+
+	post, err := p.GetPostDetails(postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.IsDeleted {
+		return nil, entity.PostNotFoundError
+	}
+
+	err = p.threadApp.CheckThreadWritable(post.Thread)
+	if err != nil {
+		return nil, err
+	}
+	return post, nil
+}
+
+// AddReaction is idempotent: reacting twice with the same reaction counts once
+func (p *PostApp) AddReaction(postID int, reaction string, nickname string) (*entity.Post, error) {
+	post, err := p.checkReactionTarget(postID, reaction)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.policyApp.CheckCanParticipate(nickname, post.Forum)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.p.AddPostReaction(postID, nickname, reaction)
+	if err != nil {
+		return nil, err
+	}
+	return p.GetPostDetails(postID)
+}
+
+func (p *PostApp) RemoveReaction(postID int, reaction string, nickname string) (*entity.Post, error) {
+	_, err := p.checkReactionTarget(postID, reaction)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.p.RemovePostReaction(postID, nickname, reaction)
+	if err != nil {
+		return nil, err
+	}
+	return p.GetPostDetails(postID)
+}
+
+// VoteForPost sets the voice of vote.Nickname on post vote.ID, a voice of 0 retracts the vote
+func (p *PostApp) VoteForPost(vote *entity.Vote) (*entity.Post, error) {
+	if vote.Voice < -1 || vote.Voice > 1 {
+		return nil, entity.InvalidVoteError
+	}
+
+	post, err := p.GetPostDetails(vote.ID)
+	if err != nil {
+		return nil, err
+	}
+	if post.IsDeleted {
+		return nil, entity.PostNotFoundError
+	}
+
+	err = p.policyApp.CheckCanParticipate(vote.Nickname, post.Forum)
+	if err != nil {
+		return nil, err
+	}
+
+	err = p.threadApp.CheckThreadWritable(post.Thread)
+	if err != nil {
+		return nil, err
+	}
+	return p.p.VoteForPost(vote)
 }
@@ -0,0 +1,129 @@
+package application
+
+import (
+	"forum/domain/entity"
+	"math/rand"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// applyDiff returns the old and the new text the diff was made of
+func applyDiff(diff []entity.DiffLine) (string, string) {
+	var oldLines, newLines []string
+	for _, line := range diff {
+		if line.Op != entity.DiffInsert {
+			oldLines = append(oldLines, line.Text)
+		}
+		if line.Op != entity.DiffDelete {
+			newLines = append(newLines, line.Text)
+		}
+	}
+	return strings.Join(oldLines, "\n"), strings.Join(newLines, "\n")
+}
+
+// lcsLength is the quadratic reference the diff has to match
+func lcsLength(a []string, b []string) int {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			switch {
+			case a[i] == b[j]:
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			case lengths[i+1][j] > lengths[i][j+1]:
+				lengths[i][j] = lengths[i+1][j]
+			default:
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+	return lengths[0][0]
+}
+
+func countEqual(diff []entity.DiffLine) int {
+	equal := 0
+	for _, line := range diff {
+		if line.Op == entity.DiffEqual {
+			equal++
+		}
+	}
+	return equal
+}
+
+func TestDiffLines(t *testing.T) {
+	diff := diffLines("a\nb\nc\nd", "a\nx\nc\nd\ne")
+	want := []entity.DiffLine{
+		{Op: entity.DiffEqual, Text: "a"},
+		{Op: entity.DiffDelete, Text: "b"},
+		{Op: entity.DiffInsert, Text: "x"},
+		{Op: entity.DiffEqual, Text: "c"},
+		{Op: entity.DiffEqual, Text: "d"},
+		{Op: entity.DiffInsert, Text: "e"},
+	}
+	if len(diff) != len(want) {
+		t.Fatalf("got %v, want %v", diff, want)
+	}
+	for i := range want {
+		if diff[i] != want[i] {
+			t.Fatalf("got %v, want %v", diff, want)
+		}
+	}
+}
+
+func TestDiffLinesIsMinimal(t *testing.T) {
+	random := rand.New(rand.NewSource(1))
+	randomText := func() string {
+		lines := make([]string, random.Intn(30))
+		for i := range lines {
+			lines[i] = strconv.Itoa(random.Intn(5))
+		}
+		return strings.Join(lines, "\n")
+	}
+
+	for i := 0; i < 500; i++ {
+		oldText, newText := randomText(), randomText()
+		diff := diffLines(oldText, newText)
+
+		gotOld, gotNew := applyDiff(diff)
+		if gotOld != oldText || gotNew != newText {
+			t.Fatalf("diff of %q and %q does not give the texts back", oldText, newText)
+		}
+		want := lcsLength(strings.Split(oldText, "\n"), strings.Split(newText, "\n"))
+		if countEqual(diff) != want {
+			t.Fatalf("diff of %q and %q keeps %v lines, the longest common subsequence has %v",
+				oldText, newText, countEqual(diff), want)
+		}
+	}
+}
+
+func TestDiffLinesFallsBackOnLargeInput(t *testing.T) {
+	oldLines := make([]string, 2001)
+	newLines := make([]string, 2001)
+	for i := range oldLines {
+		oldLines[i] = "old " + strconv.Itoa(i)
+		newLines[i] = "new " + strconv.Itoa(i)
+	}
+	oldText := "head\n" + strings.Join(oldLines, "\n") + "\ntail"
+	newText := "head\n" + strings.Join(newLines, "\n") + "\ntail"
+
+	diff := diffLines(oldText, newText)
+	gotOld, gotNew := applyDiff(diff)
+	if gotOld != oldText || gotNew != newText {
+		t.Fatal("diff does not give the texts back")
+	}
+	if diff[0].Op != entity.DiffEqual || diff[len(diff)-1].Op != entity.DiffEqual {
+		t.Error("common head and tail are not kept")
+	}
+	for i, line := range diff[1 : len(diff)-1] {
+		want := entity.DiffDelete
+		if i >= len(oldLines) {
+			want = entity.DiffInsert
+		}
+		if line.Op != want {
+			t.Fatalf("line %v is %v, want %v", i+1, line.Op, want)
+		}
+	}
+}
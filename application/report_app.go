@@ -0,0 +1,205 @@
+package application
+
+import (
+	"forum/domain/entity"
+	"forum/domain/repository"
+	"strconv"
+
+	"github.com/mailru/easyjson"
+)
+
+const defaultReportQueueLimit = 50
+
+type ReportApp struct {
+	r         repository.ReportRepository
+	postApp   PostAppInterface
+	threadApp ThreadAppInterface
+	policyApp PolicyAppInterface
+	auditApp  AuditAppInterface
+}
+
+func NewReportApp(
+	r repository.ReportRepository,
+	postApp PostAppInterface,
+	threadApp ThreadAppInterface,
+	policyApp PolicyAppInterface,
+	auditApp AuditAppInterface,
+) *ReportApp {
+	return &ReportApp{r: r, postApp: postApp, threadApp: threadApp, policyApp: policyApp, auditApp: auditApp}
+}
+
+type ReportAppInterface interface {
+	ReportPost(postID int, input *entity.ReportInput, reporter string) (*entity.Report, error)
+	ReportThread(slugOrID string, input *entity.ReportInput, reporter string) (*entity.Report, error)
+	GetReportQueue(forum string, limit int32, actor string) ([]entity.ReportQueueItem, error)
+	ResolveReports(forum string, resolution *entity.ReportResolution, actor string) error
+}
+
+func checkReportReason(reason string) error {
+	switch reason {
+	case entity.ReportReasonSpam, entity.ReportReasonAbuse, entity.ReportReasonOffTopic,
+		entity.ReportReasonIllegal, entity.ReportReasonOther:
+		return nil
+	}
+	return entity.UnknownReportReasonError
+}
+
+func (r *ReportApp) ReportPost(postID int, input *entity.ReportInput, reporter string) (*entity.Report, error) {
+	err := checkReportReason(input.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	post, err := r.postApp.GetPostDetails(postID)
+	if err != nil {
+		return nil, err
+	}
+	if post.IsDeleted {
+		return nil, entity.PostNotFoundError
+	}
+
+	report := &entity.Report{
+		Type:     entity.ReportTargetPost,
+		Target:   post.ID,
+		Forum:    post.Forum,
+		Author:   post.Author,
+		Reporter: reporter,
+		Reason:   input.Reason,
+		Comment:  input.Comment,
+	}
+	return report, r.r.CreateReport(report)
+}
+
+func (r *ReportApp) ReportThread(slugOrID string, input *entity.ReportInput, reporter string) (*entity.Report, error) {
+	err := checkReportReason(input.Reason)
+	if err != nil {
+		return nil, err
+	}
+
+	thread, err := r.threadApp.GetThread(slugOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &entity.Report{
+		Type:     entity.ReportTargetThread,
+		Target:   thread.ID,
+		Forum:    thread.Forum,
+		Author:   thread.Author,
+		Reporter: reporter,
+		Reason:   input.Reason,
+		Comment:  input.Comment,
+	}
+	return report, r.r.CreateReport(report)
+}
+
+func (r *ReportApp) GetReportQueue(forum string, limit int32, actor string) ([]entity.ReportQueueItem, error) {
+	err := r.policyApp.CheckCanModerate(actor, forum)
+	if err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultReportQueueLimit
+	}
+	return r.r.GetReportQueue(forum, limit)
+}
+
+// ResolveReports applies the moderator's action to the reported target and closes its open reports in one
+// transaction, so of two moderators resolving at once only the first acts. ban_author mutes the author in the forum,
+// global bans stay with admins
+func (r *ReportApp) ResolveReports(forum string, resolution *entity.ReportResolution, actor string) error {
+	err := r.policyApp.CheckCanModerate(actor, forum)
+	if err != nil {
+		return err
+	}
+
+	var post *entity.Post
+	var thread *entity.Thread
+	switch resolution.Type {
+	case entity.ReportTargetPost:
+		post, err = r.postApp.GetPostDetails(resolution.Target)
+		if err != nil {
+			return err
+		}
+		thread, err = r.threadApp.GetThread(strconv.Itoa(post.Thread))
+	case entity.ReportTargetThread:
+		thread, err = r.threadApp.GetThread(strconv.Itoa(resolution.Target))
+	default:
+		return entity.UnknownResolutionError
+	}
+	if err != nil {
+		return err
+	}
+
+	var action *entity.ResolutionAction
+	switch resolution.Action {
+	case entity.ResolutionDismiss:
+	case entity.ResolutionHidePost:
+		if post == nil {
+			return entity.UnknownResolutionError
+		}
+		if thread.Archived {
+			return entity.ThreadArchivedError
+		}
+		action, err = r.newResolutionAction(actor, entity.AuditPostDelete, entity.AuditTargetPost, post.ID, post.Forum, post)
+	case entity.ResolutionLockThread:
+		action, err = r.newResolutionAction(actor, entity.AuditThreadState, entity.AuditTargetThread, thread.ID, thread.Forum, thread)
+	case entity.ResolutionBanAuthor:
+		author := thread.Author
+		if post != nil {
+			author = post.Author
+		}
+		action, err = r.newResolutionAction(actor, entity.AuditBanCreate, entity.AuditTargetBan, 0, forum, nil)
+		if err == nil {
+			action.Ban = &entity.Ban{
+				Nickname: author,
+				Forum:    forum,
+				Reason:   resolution.Note,
+				IssuedBy: actor,
+			}
+		}
+	default:
+		return entity.UnknownResolutionError
+	}
+	if err != nil {
+		return err
+	}
+	if action != nil {
+		action.Thread = thread.ID
+	}
+
+	resolution.Forum = forum
+	resolution.Moderator = actor
+	audit, err := r.auditApp.NewEntry(&entity.AuditEntry{
+		Actor:      actor,
+		Action:     entity.AuditReportResolve,
+		TargetType: resolution.Type,
+		Target:     strconv.Itoa(resolution.Target),
+		Forum:      forum,
+	}, nil)
+	if err != nil {
+		return err
+	}
+
+	return r.r.ResolveReports(resolution, action, audit)
+}
+
+// newResolutionAction prepares the audit entry of the action, a ban gets its target once it exists
+func (r *ReportApp) newResolutionAction(actor string, auditAction string, targetType string, target int, forum string, before easyjson.Marshaler) (*entity.ResolutionAction, error) {
+	entry := &entity.AuditEntry{
+		Actor:      actor,
+		Action:     auditAction,
+		TargetType: targetType,
+		Forum:      forum,
+	}
+	if target != 0 {
+		entry.Target = strconv.Itoa(target)
+	}
+
+	audit, err := r.auditApp.NewEntry(entry, before)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.ResolutionAction{Audit: audit}, nil
+}
@@ -0,0 +1,105 @@
+package application
+
+import (
+	"encoding/base64"
+	"fmt"
+	"forum/domain/entity"
+	"forum/domain/repository"
+	"strconv"
+	"strings"
+)
+
+const defaultSearchLimit = 20
+const maxSearchLimit = 100
+
+type SearchApp struct {
+	s repository.SearchRepository
+}
+
+func NewSearchApp(s repository.SearchRepository) *SearchApp {
+	return &SearchApp{s: s}
+}
+
+type SearchAppInterface interface {
+	Search(text string, forum string, author string, searchType string, limit int32, cursor string) (*entity.SearchResults, error)
+}
+
+func (s *SearchApp) Search(text string, forum string, author string, searchType string, limit int32, cursor string) (*entity.SearchResults, error) {
+	if strings.TrimSpace(text) == "" {
+		return nil, entity.EmptySearchQueryError
+	}
+
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	if limit > maxSearchLimit {
+		limit = maxSearchLimit
+	}
+
+	query := &entity.SearchQuery{
+		Text:   text,
+		Forum:  forum,
+		Author: author,
+		Limit:  limit,
+	}
+
+	if cursor != "" {
+		var err error
+		query.AfterRank, query.AfterID, err = decodeSearchCursor(cursor)
+		if err != nil {
+			return nil, entity.InvalidCursorError
+		}
+	}
+
+	var results []entity.SearchResult
+	var err error
+	switch searchType {
+	case entity.SearchTypePost, "":
+		results, err = s.s.SearchPosts(query)
+	case entity.SearchTypeThread:
+		results, err = s.s.SearchThreads(query)
+	default:
+		return nil, entity.UnknownSearchTypeError
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	searchResults := &entity.SearchResults{Results: results}
+	if len(results) == int(limit) {
+		last := results[len(results)-1]
+		searchResults.Cursor = encodeSearchCursor(last.Rank, last.ID)
+	}
+
+	return searchResults, nil
+}
+
+// encodeSearchCursor keeps exact float32 rank so the next page continues right after the last result
+func encodeSearchCursor(rank float32, id int) string {
+	raw := fmt.Sprintf("%s_%d", strconv.FormatFloat(float64(rank), 'g', -1, 32), id)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeSearchCursor(cursor string) (float32, int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	parts := strings.Split(string(raw), "_")
+	if len(parts) != 2 {
+		return 0, 0, entity.InvalidCursorError
+	}
+
+	rank, err := strconv.ParseFloat(parts[0], 32)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	id, err := strconv.Atoi(parts[1])
+	if err != nil || id <= 0 {
+		return 0, 0, entity.InvalidCursorError
+	}
+
+	return float32(rank), id, nil
+}
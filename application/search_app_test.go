@@ -0,0 +1,27 @@
+package application
+
+import (
+	"encoding/base64"
+	"testing"
+)
+
+func TestSearchCursorRoundTrip(t *testing.T) {
+	for _, rank := range []float32{0, 0.0607927, 1e-7, 0.1 + 0.2} {
+		gotRank, gotID, err := decodeSearchCursor(encodeSearchCursor(rank, 15))
+		if err != nil {
+			t.Fatalf("rank %v: %v", rank, err)
+		}
+		if gotRank != rank || gotID != 15 {
+			t.Errorf("rank %v: got rank %v and id %v", rank, gotRank, gotID)
+		}
+	}
+}
+
+func TestSearchCursorRejectsForeignInput(t *testing.T) {
+	for _, raw := range []string{"", "0.5", "0.5_1_2", "high_3", "0.5_0", "0.5_x"} {
+		_, _, err := decodeSearchCursor(base64.RawURLEncoding.EncodeToString([]byte(raw)))
+		if err == nil {
+			t.Errorf("cursor %q was accepted", raw)
+		}
+	}
+}
@@ -6,20 +6,37 @@ import (
 )
 
 type ServiceApp struct {
-	s repository.ServiceRepository
+	s        repository.ServiceRepository
+	auditApp AuditAppInterface
 }
 
-func NewServiceApp(s repository.ServiceRepository) *ServiceApp {
-	return &ServiceApp{s: s}
+func NewServiceApp(s repository.ServiceRepository, auditApp AuditAppInterface) *ServiceApp {
+	return &ServiceApp{s: s, auditApp: auditApp}
 }
 
 type ServiceAppInterface interface {
-	ClearAllDate() error
+	ClearAllDate(actor string) error
 	GetDBStatus() (*entity.Status, error)
 }
 
-func (s *ServiceApp) ClearAllDate() error {
-	return s.s.ClearAllDate()
+// ClearAllDate wipes everything except the audit log, which keeps a record of the wipe itself
+func (s *ServiceApp) ClearAllDate(actor string) error {
+	status, err := s.s.GetDBStatus()
+	if err != nil {
+		return err
+	}
+
+	audit, err := s.auditApp.NewEntry(&entity.AuditEntry{
+		Actor:      actor,
+		Action:     entity.AuditServiceClear,
+		TargetType: entity.AuditTargetService,
+		Target:     "database",
+	}, status)
+	if err != nil {
+		return err
+	}
+
+	return s.s.ClearAllDate(audit)
 }
 
 func (s *ServiceApp) GetDBStatus() (*entity.Status, error) {
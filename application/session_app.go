@@ -0,0 +1,76 @@
+package application
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"forum/domain/entity"
+	"forum/domain/repository"
+	"time"
+)
+
+const sessionLifetime = 72 * time.Hour
+const sessionIDLength = 32
+
+type SessionApp struct {
+	s       repository.SessionRepository
+	userApp UserAppInterface
+}
+
+func NewSessionApp(s repository.SessionRepository, userApp UserAppInterface) *SessionApp {
+	return &SessionApp{s: s, userApp: userApp}
+}
+
+type SessionAppInterface interface {
+	Login(credentials *entity.Credentials) (*entity.Session, error)
+	Logout(sessionID string) error
+	GetUserBySession(sessionID string) (*entity.User, error)
+}
+
+func (s *SessionApp) Login(credentials *entity.Credentials) (*entity.Session, error) {
+	nickname, err := s.userApp.CheckIfUserExists(credentials.Nickname)
+	if err != nil {
+		return nil, entity.UserDoesntExistsError
+	}
+
+	// an account without a password can not log in until its owner sets one with a setup token
+	err = s.userApp.CheckPassword(nickname, credentials.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	sessionID, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	session := &entity.Session{
+		ID:       sessionID,
+		Nickname: nickname,
+		Expires:  time.Now().Add(sessionLifetime),
+	}
+
+	err = s.s.CreateSession(session)
+	if err != nil {
+		return nil, err
+	}
+
+	return session, nil
+}
+
+func (s *SessionApp) Logout(sessionID string) error {
+	return s.s.DeleteSession(sessionID)
+}
+
+func (s *SessionApp) GetUserBySession(sessionID string) (*entity.User, error) {
+	return s.s.GetUserBySession(sessionID)
+}
+
+// generateToken returns random hex string used as session_id cookie value and as password setup token
+func generateToken() (string, error) {
+	buf := make([]byte, sessionIDLength)
+	_, err := rand.Read(buf)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
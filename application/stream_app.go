@@ -0,0 +1,37 @@
+package application
+
+import (
+	"forum/domain/entity"
+	"forum/domain/repository"
+	"strconv"
+)
+
+const streamBatchSize = 100
+
+type StreamApp struct {
+	t        repository.ThreadRepository
+	listener repository.PostListener
+}
+
+func NewStreamApp(t repository.ThreadRepository, listener repository.PostListener) *StreamApp {
+	return &StreamApp{t: t, listener: listener}
+}
+
+type StreamAppInterface interface {
+	SubscribeThreadPosts(threadID int) (<-chan struct{}, func())
+	GetLastPostID(threadID int) (int, error)
+	GetPostsSince(threadID int, since int) ([]entity.Post, error)
+}
+
+func (s *StreamApp) SubscribeThreadPosts(threadID int) (<-chan struct{}, func()) {
+	return s.listener.Subscribe(threadID)
+}
+
+func (s *StreamApp) GetLastPostID(threadID int) (int, error) {
+	return s.t.GetLastPostID(threadID)
+}
+
+// GetPostsSince returns the next batch of thread posts with id greater than since, oldest first
+func (s *StreamApp) GetPostsSince(threadID int, since int) ([]entity.Post, error) {
+	return s.t.GetThreadPosts(strconv.Itoa(threadID), streamBatchSize, strconv.Itoa(since), "ASC")
+}
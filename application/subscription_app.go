@@ -0,0 +1,171 @@
+package application
+
+import (
+	"encoding/base64"
+	"forum/domain/entity"
+	"forum/domain/repository"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const defaultFeedLimit = 50
+
+type SubscriptionApp struct {
+	s         repository.SubscriptionRepository
+	threadApp ThreadAppInterface
+	forumApp  ForumAppInterface
+}
+
+func NewSubscriptionApp(s repository.SubscriptionRepository, threadApp ThreadAppInterface, forumApp ForumAppInterface) *SubscriptionApp {
+	return &SubscriptionApp{s: s, threadApp: threadApp, forumApp: forumApp}
+}
+
+type SubscriptionAppInterface interface {
+	SubscribeThread(slugOrID string, nickname string) error
+	UnsubscribeThread(slugOrID string, nickname string) error
+	SubscribeForum(slug string, nickname string) error
+	UnsubscribeForum(slug string, nickname string) error
+	GetFeed(nickname string, limit int32, since string, cursor string, desc bool, actor string) (*entity.Feed, error)
+	GetSubscriptions(nickname string, actor string) (*entity.Subscriptions, error)
+}
+
+func (s *SubscriptionApp) SubscribeThread(slugOrID string, nickname string) error {
+	thread, err := s.threadApp.GetThreadForumAndID(slugOrID)
+	if err != nil {
+		return err
+	}
+
+	return s.s.SubscribeThread(nickname, thread.ID)
+}
+
+func (s *SubscriptionApp) UnsubscribeThread(slugOrID string, nickname string) error {
+	thread, err := s.threadApp.GetThreadForumAndID(slugOrID)
+	if err != nil {
+		return err
+	}
+
+	return s.s.UnsubscribeThread(nickname, thread.ID)
+}
+
+func (s *SubscriptionApp) SubscribeForum(slug string, nickname string) error {
+	slug, err := s.forumApp.CheckForumCase(slug)
+	if err != nil {
+		return entity.ForumNotExistError
+	}
+
+	return s.s.SubscribeForum(nickname, slug)
+}
+
+func (s *SubscriptionApp) UnsubscribeForum(slug string, nickname string) error {
+	slug, err := s.forumApp.CheckForumCase(slug)
+	if err != nil {
+		return entity.ForumNotExistError
+	}
+
+	return s.s.UnsubscribeForum(nickname, slug)
+}
+
+// GetFeed is private to its owner, so actor must be the same user as nickname; the threads it serves
+// leave the feed, their posts stay until the thread is read. cursor continues after the last page and wins over since
+func (s *SubscriptionApp) GetFeed(nickname string, limit int32, since string, cursor string, desc bool, actor string) (*entity.Feed, error) {
+	if !strings.EqualFold(nickname, actor) {
+		return nil, entity.PermissionDeniedError
+	}
+
+	if limit <= 0 {
+		limit = defaultFeedLimit
+	}
+	query := &entity.FeedQuery{
+		Limit: limit,
+		Since: since,
+		Desc:  desc,
+	}
+
+	if cursor != "" {
+		var err error
+		query.AfterCreated, query.AfterType, query.AfterID, err = decodeFeedCursor(cursor)
+		if err != nil {
+			return nil, entity.InvalidCursorError
+		}
+	}
+
+	items, err := s.s.GetFeed(nickname, query)
+	if err != nil {
+		return nil, err
+	}
+
+	var threadIDs []int
+	for _, item := range items {
+		if item.Type == entity.FeedItemThread {
+			threadIDs = append(threadIDs, item.Thread.ID)
+		}
+	}
+	if len(threadIDs) != 0 {
+		err = s.s.MarkFeedThreadsSeen(nickname, threadIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	feed := &entity.Feed{Items: items}
+	if len(items) == int(limit) {
+		feed.Cursor = encodeFeedCursor(items[len(items)-1])
+	}
+	return feed, nil
+}
+
+// encodeFeedCursor keeps created to the nanosecond, the millisecond JSON timestamps would repeat or skip items
+func encodeFeedCursor(item entity.FeedItem) string {
+	var created time.Time
+	var id int
+	if item.Type == entity.FeedItemThread {
+		created, id = time.Time(item.Thread.Created), item.Thread.ID
+	} else {
+		created, id = time.Time(item.Post.Created), item.Post.ID
+	}
+
+	raw := strings.Join([]string{created.Format(time.RFC3339Nano), item.Type, strconv.Itoa(id)}, "_")
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeFeedCursor(cursor string) (time.Time, string, int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, "", 0, err
+	}
+
+	parts := strings.Split(string(raw), "_")
+	if len(parts) != 3 || (parts[1] != entity.FeedItemThread && parts[1] != entity.FeedItemPost) {
+		return time.Time{}, "", 0, entity.InvalidCursorError
+	}
+
+	created, err := time.Parse(time.RFC3339Nano, parts[0])
+	if err != nil {
+		return time.Time{}, "", 0, err
+	}
+
+	id, err := strconv.Atoi(parts[2])
+	if err != nil || id <= 0 {
+		return time.Time{}, "", 0, entity.InvalidCursorError
+	}
+
+	return created, parts[1], id, nil
+}
+
+func (s *SubscriptionApp) GetSubscriptions(nickname string, actor string) (*entity.Subscriptions, error) {
+	if !strings.EqualFold(nickname, actor) {
+		return nil, entity.PermissionDeniedError
+	}
+
+	threads, err := s.s.GetSubscribedThreads(nickname)
+	if err != nil {
+		return nil, err
+	}
+
+	forums, err := s.s.GetSubscribedForums(nickname)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.Subscriptions{Threads: threads, Forums: forums}, nil
+}
@@ -0,0 +1,54 @@
+package application
+
+import (
+	"encoding/base64"
+	"forum/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+)
+
+func TestFeedCursorRoundTrip(t *testing.T) {
+	created := time.Date(2021, 5, 14, 10, 30, 0, 123456000, time.UTC)
+
+	for _, item := range []entity.FeedItem{
+		{Type: entity.FeedItemThread, Thread: &entity.Thread{ID: 7, Created: strfmt.DateTime(created)}},
+		{Type: entity.FeedItemPost, Post: &entity.Post{ID: 42, Created: strfmt.DateTime(created)}},
+	} {
+		afterCreated, afterType, afterID, err := decodeFeedCursor(encodeFeedCursor(item))
+		if err != nil {
+			t.Fatalf("%v cursor: %v", item.Type, err)
+		}
+		if !afterCreated.Equal(created) {
+			t.Errorf("%v cursor: created %v, want %v", item.Type, afterCreated, created)
+		}
+		if afterType != item.Type {
+			t.Errorf("%v cursor: type %v", item.Type, afterType)
+		}
+		if item.Type == entity.FeedItemThread && afterID != 7 || item.Type == entity.FeedItemPost && afterID != 42 {
+			t.Errorf("%v cursor: id %v", item.Type, afterID)
+		}
+	}
+}
+
+func TestFeedCursorRejectsForeignInput(t *testing.T) {
+	for _, raw := range []string{
+		"",
+		"2021-05-14T10:30:00Z_thread",
+		"2021-05-14T10:30:00Z_message_3",
+		"2021-05-14T10:30:00Z_post_0",
+		"2021-05-14T10:30:00Z_post_x",
+		"yesterday_post_3",
+	} {
+		_, _, _, err := decodeFeedCursor(base64.RawURLEncoding.EncodeToString([]byte(raw)))
+		if err == nil {
+			t.Errorf("cursor %q was accepted", raw)
+		}
+	}
+
+	_, _, _, err := decodeFeedCursor("not base64!")
+	if err == nil {
+		t.Error("cursor that is not base64 was accepted")
+	}
+}
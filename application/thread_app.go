@@ -1,18 +1,26 @@
 package application
 
 import (
+	"fmt"
 	"forum/domain/entity"
 	"forum/domain/repository"
 	"strconv"
+	"strings"
+	"unicode/utf8"
 )
 
+const defaultTagLimit = 10
+const maxTagLimit = 50
+
 type ThreadApp struct {
-	t repository.ThreadRepository
-	forumApp ForumAppInterface
+	t         repository.ThreadRepository
+	forumApp  ForumAppInterface
+	policyApp PolicyAppInterface
+	auditApp  AuditAppInterface
 }
 
-func NewThreadApp(f repository.ThreadRepository, forumApp ForumAppInterface) *ThreadApp {
-	return &ThreadApp{t: f, forumApp: forumApp}
+func NewThreadApp(f repository.ThreadRepository, forumApp ForumAppInterface, policyApp PolicyAppInterface, auditApp AuditAppInterface) *ThreadApp {
+	return &ThreadApp{t: f, forumApp: forumApp, policyApp: policyApp, auditApp: auditApp}
 }
 
 type ThreadAppInterface interface {
@@ -21,22 +29,87 @@ type ThreadAppInterface interface {
 	GetThreadPosts(slug string, limit int32, since string, sort string, desc bool) ([]entity.Post, error)
 	CheckThread(slugOrID string) error
 	VoteForThread(vote *entity.Vote) (*entity.Thread, error)
+	RetractVote(slugOrID string, nickname string) (*entity.Thread, error)
+	GetThreadVotes(slugOrID string, limit int32, since string, desc bool) ([]entity.ThreadVote, error)
+	GetUserVotes(nickname string, limit int32, since int, desc bool) ([]entity.ThreadVote, error)
 	GetThread(slugOrID string) (*entity.Thread, error)
 	GetThreadForumAndID(slugOrID string) (*entity.Thread, error)
-	GetThreadsByForumSlug(slug string, limit int32, since string, desc bool) ([]entity.Thread, error)
-	UpdateThread(slugOrID string, newThreadData *entity.Thread) error
+	GetThreadsByForumSlug(slug string, limit int32, since string, desc bool, tag string) ([]entity.Thread, error)
+	GetThreadsByTag(tag string, limit int32, since string, desc bool) ([]entity.Thread, error)
+	GetForumTags(slug string) ([]entity.TagCount, error)
+	AutocompleteTags(prefix string, limit int32) ([]entity.TagCount, error)
+	UpdateThread(slugOrID string, newThreadData *entity.Thread, nickname string) error
+	DeleteThread(slugOrID string, nickname string) error
+	SetThreadState(slugOrID string, state *entity.ThreadStateInput, nickname string) (*entity.Thread, error)
+	MoveThread(slugOrID string, input *entity.ThreadMoveInput, nickname string) (*entity.Thread, error)
+	CheckThreadWritable(threadID int) error
+	MarkPostsRead(nickname string, posts []entity.Post, sort string, desc bool) error
+	SetReadMarker(slugOrID string, postID int, nickname string) (*entity.Thread, error)
+	AddUnreadCounts(threads []entity.Thread, nickname string) error
 }
 
 func (t *ThreadApp) CreatePosts(thread *entity.Thread, posts []entity.Post) error {
+	checked := make(map[string]bool)
+	for _, post := range posts {
+		if checked[post.Author] {
+			continue
+		}
+		err := t.policyApp.CheckCanParticipate(post.Author, thread.Forum)
+		if err != nil {
+			return err
+		}
+		checked[post.Author] = true
+	}
+
 	return t.t.CreatePosts(thread, posts)
 }
 
+// normalizeTags lowercases and deduplicates tags, keeping their order; nil stays nil so updates can leave tags alone
+func normalizeTags(tags []string) ([]string, error) {
+	if tags == nil {
+		return nil, nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" || seen[tag] {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > entity.MaxTagLength {
+			return nil, entity.InvalidTagsError
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+
+	if len(normalized) > entity.MaxThreadTags {
+		return nil, entity.InvalidTagsError
+	}
+	return normalized, nil
+}
+
 func (t *ThreadApp) CreateThread(thread *entity.Thread) error {
 	var err error
-	thread.Forum, err = t.forumApp.CheckForumCase(thread.Forum)
+	thread.Tags, err = normalizeTags(thread.Tags)
+	if err != nil {
+		return err
+	}
+
+	forum, err := t.forumApp.GetForumDetails(thread.Forum)
 	if err != nil {
 		return entity.ForumNotExistError
 	}
+	if forum.IsCategory {
+		return entity.ForumIsCategoryError
+	}
+	thread.Forum = forum.Slug
+
+	err = t.policyApp.CheckCanParticipate(thread.Author, thread.Forum)
+	if err != nil {
+		return err
+	}
 	return t.t.CreateThread(thread)
 }
 
@@ -66,13 +139,74 @@ func (t *ThreadApp) CheckThread(slugOrID string) error {
 		return err
 	}
 
-	return 	t.t.CheckThreadByID(id)
+	return t.t.CheckThreadByID(id)
 }
 
+// VoteForThread sets the voice of vote.Nickname on the thread, a voice of 0 retracts the vote
 func (t *ThreadApp) VoteForThread(vote *entity.Vote) (*entity.Thread, error) {
+	if vote.Voice < -1 || vote.Voice > 1 {
+		return nil, entity.InvalidVoteError
+	}
+
+	slugOrID := vote.Slug
+	if vote.ID != 0 {
+		slugOrID = strconv.Itoa(vote.ID)
+	}
+
+	if vote.Voice == 0 {
+		return t.RetractVote(slugOrID, vote.Nickname)
+	}
+
+	thread, err := t.t.GetThreadForumAndID(slugOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.policyApp.CheckCanParticipate(vote.Nickname, thread.Forum)
+	if err != nil {
+		return nil, err
+	}
 	return t.t.VoteForThread(vote)
 }
 
+func (t *ThreadApp) RetractVote(slugOrID string, nickname string) (*entity.Thread, error) {
+	thread, err := t.GetThread(slugOrID)
+	if err != nil {
+		return nil, err
+	}
+	if thread.Archived {
+		return nil, entity.ThreadArchivedError
+	}
+
+	err = t.t.RetractThreadVote(nickname, thread.ID)
+	if err != nil {
+		return nil, err
+	}
+	return t.t.GetThreadByID(thread.ID)
+}
+
+func (t *ThreadApp) GetThreadVotes(slugOrID string, limit int32, since string, desc bool) ([]entity.ThreadVote, error) {
+	thread, err := t.t.GetThreadForumAndID(slugOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	order, compare := "ASC", ">"
+	if desc {
+		order, compare = "DESC", "<"
+	}
+	return t.t.GetThreadVotes(thread.ID, limit, since, order, compare)
+}
+
+// GetUserVotes lists the threads the user voted on, paged by thread id
+func (t *ThreadApp) GetUserVotes(nickname string, limit int32, since int, desc bool) ([]entity.ThreadVote, error) {
+	order, compare := "ASC", ">"
+	if desc {
+		order, compare = "DESC", "<"
+	}
+	return t.t.GetUserThreadVotes(nickname, limit, since, order, compare)
+}
+
 func (t *ThreadApp) GetThread(slugOrID string) (*entity.Thread, error) {
 	id, err := strconv.Atoi(slugOrID)
 	if err != nil {
@@ -85,11 +219,53 @@ func (t *ThreadApp) GetThreadForumAndID(slugOrID string) (*entity.Thread, error)
 	return t.t.GetThreadForumAndID(slugOrID)
 }
 
-func (t *ThreadApp) GetThreadsByForumSlug(slug string, limit int32, since string, desc bool) ([]entity.Thread, error) {
-	return t.t.GetThreadsByForumSlug(slug , limit, since , desc)
+func (t *ThreadApp) GetThreadsByForumSlug(slug string, limit int32, since string, desc bool, tag string) ([]entity.Thread, error) {
+	return t.t.GetThreadsByForumSlug(slug, limit, since, desc, strings.ToLower(strings.TrimSpace(tag)))
+}
+
+func (t *ThreadApp) GetThreadsByTag(tag string, limit int32, since string, desc bool) ([]entity.Thread, error) {
+	return t.t.GetThreadsByTag(strings.ToLower(strings.TrimSpace(tag)), limit, since, desc)
+}
+
+func (t *ThreadApp) GetForumTags(slug string) ([]entity.TagCount, error) {
+	slug, err := t.forumApp.CheckForumCase(slug)
+	if err != nil {
+		return nil, entity.ForumNotExistError
+	}
+
+	return t.t.GetForumTags(slug)
+}
+
+var tagPrefixEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (t *ThreadApp) AutocompleteTags(prefix string, limit int32) ([]entity.TagCount, error) {
+	if limit <= 0 {
+		limit = defaultTagLimit
+	}
+	if limit > maxTagLimit {
+		limit = maxTagLimit
+	}
+
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	return t.t.SearchTags(tagPrefixEscaper.Replace(prefix), limit)
 }
 
-func (t *ThreadApp) UpdateThread(slugOrID string, newThreadData *entity.Thread) error {
+func (t *ThreadApp) UpdateThread(slugOrID string, newThreadData *entity.Thread, nickname string) error {
+	thread, err := t.GetThread(slugOrID)
+	if err != nil {
+		return err
+	}
+
+	err = t.policyApp.CheckCanEdit(nickname, thread.Forum, thread.Author)
+	if err != nil {
+		return err
+	}
+
+	newThreadData.Tags, err = normalizeTags(newThreadData.Tags)
+	if err != nil {
+		return err
+	}
+
 	newThreadData.Slug = &slugOrID
 	id, err := strconv.Atoi(slugOrID)
 	if err != nil {
@@ -97,5 +273,196 @@ func (t *ThreadApp) UpdateThread(slugOrID string, newThreadData *entity.Thread)
 	}
 
 	newThreadData.ID = id
-	return t.t.UpdateThread(newThreadData)
-}
\ No newline at end of file
+	audit, err := t.auditApp.NewEntry(&entity.AuditEntry{
+		Actor:      nickname,
+		Action:     entity.AuditThreadUpdate,
+		TargetType: entity.AuditTargetThread,
+		Target:     strconv.Itoa(thread.ID),
+		Forum:      thread.Forum,
+	}, thread)
+	if err != nil {
+		return err
+	}
+
+	return t.t.UpdateThread(newThreadData, audit)
+}
+
+func (t *ThreadApp) DeleteThread(slugOrID string, nickname string) error {
+	thread, err := t.GetThread(slugOrID)
+	if err != nil {
+		return err
+	}
+
+	err = t.policyApp.CheckCanModerate(nickname, thread.Forum)
+	if err != nil {
+		return err
+	}
+
+	audit, err := t.auditApp.NewEntry(&entity.AuditEntry{
+		Actor:      nickname,
+		Action:     entity.AuditThreadDelete,
+		TargetType: entity.AuditTargetThread,
+		Target:     strconv.Itoa(thread.ID),
+		Forum:      thread.Forum,
+	}, thread)
+	if err != nil {
+		return err
+	}
+
+	return t.t.DeleteThread(thread, audit)
+}
+
+// SetThreadState locks, pins or archives the thread; only moderators of its forum may do that
+func (t *ThreadApp) SetThreadState(slugOrID string, state *entity.ThreadStateInput, nickname string) (*entity.Thread, error) {
+	thread, err := t.GetThread(slugOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.policyApp.CheckCanModerate(nickname, thread.Forum)
+	if err != nil {
+		return nil, err
+	}
+
+	audit, err := t.auditApp.NewEntry(&entity.AuditEntry{
+		Actor:      nickname,
+		Action:     entity.AuditThreadState,
+		TargetType: entity.AuditTargetThread,
+		Target:     strconv.Itoa(thread.ID),
+		Forum:      thread.Forum,
+	}, thread)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.t.UpdateThreadState(thread.ID, state, audit)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.t.GetThreadByID(thread.ID)
+}
+
+// MoveThread needs a moderator of both forums; threads can not be moved into a category,
+// and a redirect stub stays where it is, only the thread it points at moves
+func (t *ThreadApp) MoveThread(slugOrID string, input *entity.ThreadMoveInput, nickname string) (*entity.Thread, error) {
+	thread, err := t.GetThread(slugOrID)
+	if err != nil {
+		return nil, err
+	}
+	if thread.MovedTo != 0 {
+		return nil, entity.ThreadIsRedirectError
+	}
+
+	forum, err := t.forumApp.GetForumDetails(input.Forum)
+	if err != nil {
+		return nil, entity.ForumNotExistError
+	}
+	if forum.IsCategory {
+		return nil, entity.ForumIsCategoryError
+	}
+	if strings.EqualFold(forum.Slug, thread.Forum) {
+		return nil, entity.DataError
+	}
+
+	for _, slug := range []string{thread.Forum, forum.Slug} {
+		err = t.policyApp.CheckCanModerate(nickname, slug)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var stub *entity.Thread
+	if input.LeaveRedirect {
+		stub = &entity.Thread{
+			Author:  thread.Author,
+			Created: thread.Created,
+			Forum:   thread.Forum,
+			Title:   thread.Title,
+			Message: fmt.Sprintf(entity.MovedThreadMessage, forum.Slug, thread.ID),
+			MovedTo: thread.ID,
+		}
+	}
+
+	audit, err := t.auditApp.NewEntry(&entity.AuditEntry{
+		Actor:      nickname,
+		Action:     entity.AuditThreadMove,
+		TargetType: entity.AuditTargetThread,
+		Target:     strconv.Itoa(thread.ID),
+		Forum:      thread.Forum,
+	}, thread)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.t.MoveThread(thread, forum.Slug, stub, audit)
+	if err != nil {
+		return nil, err
+	}
+
+	return t.t.GetThreadByID(thread.ID)
+}
+
+// CheckThreadWritable refuses changes to the posts of an archived thread
+func (t *ThreadApp) CheckThreadWritable(threadID int) error {
+	thread, err := t.t.GetThreadByID(threadID)
+	if err != nil {
+		return err
+	}
+
+	if thread.Archived {
+		return entity.ThreadArchivedError
+	}
+	return nil
+}
+
+// MarkPostsRead moves the read marker of the viewer past the served posts; only a flat ascending page
+// holds every post between its first and last id, tree and descending pages leave the marker alone
+func (t *ThreadApp) MarkPostsRead(nickname string, posts []entity.Post, sort string, desc bool) error {
+	if len(posts) == 0 || desc || (sort != "" && sort != "flat") {
+		return nil
+	}
+
+	return t.t.MarkThreadRead(nickname, posts[0].Thread, posts[0].ID, posts[len(posts)-1].ID)
+}
+
+// SetReadMarker returns the thread with its unread count after the marker moved
+func (t *ThreadApp) SetReadMarker(slugOrID string, postID int, nickname string) (*entity.Thread, error) {
+	thread, err := t.GetThread(slugOrID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = t.t.SetReadMarker(nickname, thread.ID, postID)
+	if err != nil {
+		return nil, err
+	}
+
+	unread, err := t.t.GetUnreadCounts(nickname, []int{thread.ID})
+	if err != nil {
+		return nil, err
+	}
+	thread.Unread = unread[thread.ID]
+	return thread, nil
+}
+
+func (t *ThreadApp) AddUnreadCounts(threads []entity.Thread, nickname string) error {
+	if len(threads) == 0 {
+		return nil
+	}
+
+	threadIDs := make([]int, 0, len(threads))
+	for _, thread := range threads {
+		threadIDs = append(threadIDs, thread.ID)
+	}
+
+	unread, err := t.t.GetUnreadCounts(nickname, threadIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range threads {
+		threads[i].Unread = unread[threads[i].ID]
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package application
+
+import (
+	"errors"
+	"forum/domain/entity"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags, err := normalizeTags([]string{" Go ", "postgres", "GO", "", "  ", "Postgres", "sql"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"go", "postgres", "sql"}
+	if !reflect.DeepEqual(tags, want) {
+		t.Errorf("got %v, want %v", tags, want)
+	}
+
+	tags, err = normalizeTags(nil)
+	if err != nil || tags != nil {
+		t.Errorf("nil tags became %v, %v", tags, err)
+	}
+
+	tags, err = normalizeTags([]string{})
+	if err != nil || tags == nil || len(tags) != 0 {
+		t.Errorf("empty tags became %v, %v", tags, err)
+	}
+}
+
+func TestNormalizeTagsLimits(t *testing.T) {
+	_, err := normalizeTags([]string{strings.Repeat("ж", entity.MaxTagLength)})
+	if err != nil {
+		t.Errorf("tag of %v runes was refused: %v", entity.MaxTagLength, err)
+	}
+
+	_, err = normalizeTags([]string{strings.Repeat("a", entity.MaxTagLength+1)})
+	if !errors.Is(err, entity.InvalidTagsError) {
+		t.Errorf("too long tag: got %v", err)
+	}
+
+	tooMany := make([]string, entity.MaxThreadTags+1)
+	for i := range tooMany {
+		tooMany[i] = strings.Repeat("t", i+1)
+	}
+	_, err = normalizeTags(tooMany)
+	if !errors.Is(err, entity.InvalidTagsError) {
+		t.Errorf("too many tags: got %v", err)
+	}
+
+	// duplicates do not count against the limit
+	_, err = normalizeTags(append(tooMany[:entity.MaxThreadTags], tooMany[0]))
+	if err != nil {
+		t.Errorf("duplicate tag counted against the limit: %v", err)
+	}
+}
@@ -1,29 +1,53 @@
 package application
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"forum/domain/entity"
 	"forum/domain/repository"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"time"
 )
 
+const minPasswordLength = 8
+const maxPasswordLength = 72 // bcrypt ignores everything past 72 bytes
+const passwordSetupTokenLifetime = 24 * time.Hour
+
 type UserApp struct {
-	us       repository.UserRepository
+	us           repository.UserRepository
+	r            repository.RoleRepository
+	passwordCost int
 }
 
-func NewUserApp(us repository.UserRepository) *UserApp {
-	return &UserApp{us: us}
+func NewUserApp(us repository.UserRepository, r repository.RoleRepository, passwordCost int) *UserApp {
+	if passwordCost < bcrypt.MinCost || passwordCost > bcrypt.MaxCost {
+		passwordCost = bcrypt.DefaultCost
+	}
+	return &UserApp{us: us, r: r, passwordCost: passwordCost}
 }
 
 type UserAppInterface interface {
-	CreateUser(user *entity.User) error
+	CreateUser(user *entity.User) (*entity.PasswordSetupToken, error)
 	CheckIfUserExists(nickname string) (string, error)
 	GetUserByNickname(nickname string) (*entity.User, error)
 	UpdateUser(newUser *entity.User) (*entity.User, error)
 	GetUserNicknameWithEmail(email string) (string, error)
 	GetUsersWithNicknameAndEmail(nickname, email string) ([]entity.User, error)
+	SetPassword(nickname string, input *entity.PasswordInput) error
+	IssuePasswordSetupToken(nickname string, actor string) (*entity.PasswordSetupToken, error)
+	ChangePassword(nickname string, input *entity.PasswordInput) error
+	CheckPassword(nickname string, password string) error
 }
 
-func (us *UserApp) CreateUser(user *entity.User) error {
-	return us.us.CreateUser(user)
+// CreateUser hands the setup token of the first password to whoever created the account
+func (us *UserApp) CreateUser(user *entity.User) (*entity.PasswordSetupToken, error) {
+	err := us.us.CreateUser(user)
+	if err != nil {
+		return nil, err
+	}
+
+	return us.newPasswordSetupToken(user.Nickname)
 }
 
 func (us *UserApp) CheckIfUserExists(nickname string) (string, error) {
@@ -40,6 +64,8 @@ func (us *UserApp) UpdateUser(newUser *entity.User) (*entity.User, error) {
 		return nil, err
 	}
 	newUser.ID = userFromDB.ID
+	newUser.Avatar = userFromDB.Avatar
+	newUser.Karma = userFromDB.Karma
 	if newUser.Fullname == "" {
 		newUser.Fullname = userFromDB.Fullname
 	}
@@ -62,3 +88,127 @@ func (us *UserApp) GetUserNicknameWithEmail(email string) (string, error) {
 func (us *UserApp) GetUsersWithNicknameAndEmail(nickname, email string) ([]entity.User, error) {
 	return us.us.GetUsersWithNicknameAndEmail(nickname, email)
 }
+
+// SetPassword stores the first password of an account and spends its setup token, use ChangePassword to replace it
+func (us *UserApp) SetPassword(nickname string, input *entity.PasswordInput) error {
+	if input.Token == "" {
+		return entity.InvalidSetupTokenError
+	}
+
+	hash, err := us.hashPassword(input.Password)
+	if err != nil {
+		return err
+	}
+
+	return us.us.SetFirstPasswordHash(nickname, hashSetupToken(input.Token), hash)
+}
+
+// IssuePasswordSetupToken lets an admin hand a setup token to the owner of an account that has no password yet,
+// after checking who they are; a new token replaces the previous one
+func (us *UserApp) IssuePasswordSetupToken(nickname string, actor string) (*entity.PasswordSetupToken, error) {
+	role, err := us.r.GetRole(actor, "")
+	if err != nil {
+		return nil, err
+	}
+	if role != entity.RoleAdmin {
+		return nil, entity.PermissionDeniedError
+	}
+
+	nickname, err = us.us.CheckIfUserExists(nickname)
+	if err != nil {
+		return nil, entity.UserDoesntExistsError
+	}
+
+	_, err = us.us.GetPasswordHash(nickname)
+	if err == nil {
+		return nil, entity.PasswordAlreadySetError
+	}
+	if err != entity.PasswordNotSetError {
+		return nil, err
+	}
+
+	return us.newPasswordSetupToken(nickname)
+}
+
+func (us *UserApp) newPasswordSetupToken(nickname string) (*entity.PasswordSetupToken, error) {
+	token, err := generateToken()
+	if err != nil {
+		return nil, err
+	}
+
+	setupToken := &entity.PasswordSetupToken{
+		Nickname: nickname,
+		Token:    token,
+		Expires:  time.Now().Add(passwordSetupTokenLifetime),
+	}
+	return setupToken, us.us.SetPasswordSetupToken(nickname, hashSetupToken(token), setupToken.Expires)
+}
+
+func hashSetupToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func (us *UserApp) ChangePassword(nickname string, input *entity.PasswordInput) error {
+	err := us.CheckPassword(nickname, input.OldPassword)
+	if err != nil {
+		return err
+	}
+
+	return us.storePassword(nickname, input.Password)
+}
+
+// CheckPassword compares password with the stored hash and rehashes it
+// if it was produced with a cost other than the configured one
+func (us *UserApp) CheckPassword(nickname string, password string) error {
+	hash, err := us.us.GetPasswordHash(nickname)
+	if err != nil {
+		return err
+	}
+
+	err = bcrypt.CompareHashAndPassword(hash, []byte(password))
+	if err != nil {
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return entity.WrongPasswordError
+		}
+		return err
+	}
+
+	cost, err := bcrypt.Cost(hash)
+	if err != nil || cost != us.passwordCost {
+		return us.storePassword(nickname, password)
+	}
+
+	return nil
+}
+
+func (us *UserApp) storePassword(nickname string, password string) error {
+	hash, err := us.hashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	return us.us.SetPasswordHash(nickname, hash)
+}
+
+func (us *UserApp) hashPassword(password string) ([]byte, error) {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return nil, entity.WeakPasswordError
+	}
+
+	return bcrypt.GenerateFromPassword([]byte(password), us.passwordCost)
+}
+
+// resolveOtherUser turns the nickname into its stored spelling for actions one user takes on another,
+// users that do not exist and the actor themselves are refused
+func resolveOtherUser(userApp UserAppInterface, nickname string, actor string) (string, error) {
+	nickname, err := userApp.CheckIfUserExists(nickname)
+	if err != nil {
+		return "", entity.UserDoesntExistsError
+	}
+	if strings.EqualFold(nickname, actor) {
+		return "", entity.DataError
+	}
+
+	return nickname, nil
+}
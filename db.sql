@@ -5,32 +5,113 @@ DROP TABLE IF EXISTS threads CASCADE;
 DROP TABLE IF EXISTS Thread_vote CASCADE;
 DROP TABLE IF EXISTS posts CASCADE;
 DROP TABLE IF EXISTS Forum_user CASCADE;
+DROP TABLE IF EXISTS sessions CASCADE;
+DROP TABLE IF EXISTS user_credentials CASCADE;
+DROP TABLE IF EXISTS password_setup_tokens CASCADE;
+DROP TABLE IF EXISTS notifications CASCADE;
+DROP TABLE IF EXISTS global_roles CASCADE;
+DROP TABLE IF EXISTS forum_moderators CASCADE;
+DROP TABLE IF EXISTS bans CASCADE;
+DROP TABLE IF EXISTS reports CASCADE;
+DROP TABLE IF EXISTS report_resolutions CASCADE;
+DROP TABLE IF EXISTS audit_log CASCADE;
+DROP TABLE IF EXISTS post_revisions CASCADE;
+DROP TABLE IF EXISTS post_reaction CASCADE;
+DROP TABLE IF EXISTS Post_vote CASCADE;
+DROP TABLE IF EXISTS thread_subscriptions CASCADE;
+DROP TABLE IF EXISTS forum_subscriptions CASCADE;
+DROP TABLE IF EXISTS read_markers CASCADE;
+DROP TABLE IF EXISTS conversations CASCADE;
+DROP TABLE IF EXISTS conversation_members CASCADE;
+DROP TABLE IF EXISTS messages CASCADE;
+DROP TABLE IF EXISTS user_blocks CASCADE;
+DROP TABLE IF EXISTS user_ignores CASCADE;
+DROP TABLE IF EXISTS forum_tags CASCADE;
 
 CREATE UNLOGGED TABLE IF NOT EXISTS users (
     id SERIAL UNIQUE NOT NULL,
     nickname CITEXT NOT NULL PRIMARY KEY,
     email    CITEXT NOT NULL UNIQUE,
     fullname CITEXT NOT NULL,
-    about    TEXT   NOT NULL
+    about    TEXT   NOT NULL,
+    avatar   TEXT   NOT NULL DEFAULT '',
+    karma    INT    NOT NULL DEFAULT 0
 );
 
 CREATE INDEX index_users_nickname_hash ON users USING HASH (nickname);
 CREATE INDEX index_users_email_hash ON users USING HASH (email);
 CREATE INDEX index_users_id ON users USING HASH (id);
 
+CREATE UNLOGGED TABLE IF NOT EXISTS sessions (
+    session_id TEXT PRIMARY KEY,
+    nickname   CITEXT NOT NULL REFERENCES users(nickname) ON DELETE CASCADE,
+    created    TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    expires    TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX index_sessions_nickname ON sessions USING HASH (nickname);
+
+CREATE UNLOGGED TABLE IF NOT EXISTS user_credentials (
+    nickname      CITEXT PRIMARY KEY REFERENCES users(nickname) ON DELETE CASCADE,
+    password_hash TEXT NOT NULL,
+    updated       TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+-- a first password can only be set with a one-time token, only its sha256 is stored
+CREATE UNLOGGED TABLE IF NOT EXISTS password_setup_tokens (
+    nickname   CITEXT PRIMARY KEY REFERENCES users(nickname) ON DELETE CASCADE,
+    token_hash TEXT NOT NULL,
+    expires    TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
 CREATE UNLOGGED TABLE IF NOT EXISTS forums (
     id           SERIAL,
     slug         CITEXT PRIMARY KEY,
     post_count   INT    NOT NULL DEFAULT 0,
     thread_count INT       NOT NULL DEFAULT 0,
     title        TEXT      NOT NULL,
-    user_nickname  CITEXT      NOT NULL
+    user_nickname  CITEXT      NOT NULL,
+    parent       CITEXT REFERENCES forums(slug),
+    path         CITEXT[]  NOT NULL DEFAULT '{}',
+    is_category  BOOLEAN   NOT NULL DEFAULT FALSE,
+    total_post_count   INT NOT NULL DEFAULT 0,
+    total_thread_count INT NOT NULL DEFAULT 0
 );
 
 CREATE INDEX index_forums ON forums (slug, title, user_nickname, post_count, thread_count);
 CREATE INDEX index_forums_users_foreign ON forums USING HASH (user_nickname);
 CREATE INDEX index_forums_slug_hash ON forums USING HASH (slug);
 CREATE INDEX index_forums_id_hash ON forums USING HASH (id);
+CREATE INDEX index_forums_parent ON forums (parent);
+
+-- path lists the slugs from the root category down to the forum itself
+CREATE OR REPLACE FUNCTION set_forum_path()
+    RETURNS TRIGGER AS $set_forum_path$
+BEGIN
+NEW.path = COALESCE((SELECT path FROM forums WHERE slug = NEW.parent), '{}') || NEW.slug;
+RETURN NEW;
+END;
+$set_forum_path$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS set_forum_path ON forums;
+CREATE TRIGGER set_forum_path BEFORE INSERT ON forums FOR EACH ROW EXECUTE PROCEDURE set_forum_path();
+
+-- every change of the own counters of a forum is rolled up into the totals of the forum and its ancestors
+CREATE OR REPLACE FUNCTION forum_totals_counter()
+    RETURNS TRIGGER AS $forum_totals_counter$
+BEGIN
+UPDATE forums
+SET total_thread_count = total_thread_count + NEW.thread_count - OLD.thread_count,
+    total_post_count = total_post_count + NEW.post_count - OLD.post_count
+WHERE slug = ANY(NEW.path);
+RETURN NULL;
+END;
+$forum_totals_counter$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS forum_totals_counter ON forums;
+CREATE TRIGGER forum_totals_counter AFTER UPDATE OF thread_count, post_count ON forums
+    FOR EACH ROW WHEN (OLD.thread_count IS DISTINCT FROM NEW.thread_count OR OLD.post_count IS DISTINCT FROM NEW.post_count)
+    EXECUTE PROCEDURE forum_totals_counter();
 
 CREATE UNLOGGED TABLE IF NOT EXISTS threads (
     id         SERIAL PRIMARY KEY ,
@@ -41,6 +122,10 @@ CREATE UNLOGGED TABLE IF NOT EXISTS threads (
     slug      CITEXT      UNIQUE,
     title     TEXT        NOT NULL,
     votes     INT         NOT NULL DEFAULT 0,
+    locked    BOOLEAN     NOT NULL DEFAULT FALSE,
+    pinned    BOOLEAN     NOT NULL DEFAULT FALSE,
+    archived  BOOLEAN     NOT NULL DEFAULT FALSE,
+    search    TSVECTOR,
     FOREIGN KEY (forum) REFERENCES Forums (slug) ON DELETE CASCADE,
     FOREIGN KEY (author) REFERENCES Users (nickname) ON DELETE CASCADE
 );
@@ -71,10 +156,15 @@ CREATE UNLOGGED TABLE posts (
     author CITEXT NOT NULL REFERENCES users(nickname),
     created TIMESTAMP WITH TIME ZONE DEFAULT now(),
     isEdited BOOLEAN DEFAULT FALSE,
+    isDeleted BOOLEAN DEFAULT FALSE,
+    editor CITEXT,
     msg      TEXT  NOT NULL,
     parent   INTEGER,
     forum CITEXT NOT NULL,
-    thread INTEGER NOT NULL
+    thread INTEGER NOT NULL,
+    search TSVECTOR,
+    reactions JSONB NOT NULL DEFAULT '{}',
+    votes INT NOT NULL DEFAULT 0
 );
 
 CREATE INDEX index_posts_thread on posts (thread);
@@ -118,11 +208,18 @@ END;
 $add_forum_user_thread$ LANGUAGE plpgsql;
 
 
+-- the original message is saved lazily as revision 1 on the first edit, so unedited posts cost nothing
 CREATE OR REPLACE FUNCTION set_edited() RETURNS TRIGGER AS $set_edited$
 BEGIN
     IF (NEW.msg = OLD.msg)
     THEN RETURN NULL;
 END IF;
+    IF NOT EXISTS (SELECT 1 FROM post_revisions WHERE post = NEW.id) THEN
+        INSERT INTO post_revisions (post, revision, editor, msg, created)
+        VALUES (OLD.id, 1, OLD.author, OLD.msg, OLD.created);
+END IF;
+INSERT INTO post_revisions (post, revision, editor, msg)
+SELECT NEW.id, max(revision) + 1, COALESCE(NEW.editor, NEW.author), NEW.msg FROM post_revisions WHERE post = NEW.id;
 UPDATE posts SET isEdited = TRUE
 WHERE id=NEW.id;
 RETURN NULL;
@@ -181,10 +278,7 @@ BEGIN
         RETURN NULL;
 END IF;
 UPDATE threads
-SET
-    votes = votes + CASE WHEN NEW.vote = -1
-                             THEN -2
-                         ELSE 2 END
+SET votes = votes + NEW.vote - OLD.vote
 WHERE id = NEW.thread_id;
 RETURN NULL;
 END;
@@ -193,6 +287,18 @@ $vote_update$ LANGUAGE  plpgsql;
 DROP TRIGGER IF EXISTS vote_update ON Thread_vote;
 CREATE TRIGGER vote_update AFTER UPDATE ON Thread_vote FOR EACH ROW EXECUTE PROCEDURE vote_update();
 
+CREATE OR REPLACE FUNCTION vote_delete() RETURNS TRIGGER AS $vote_delete$
+BEGIN
+UPDATE threads
+SET votes = votes - OLD.vote
+WHERE id = OLD.thread_id;
+RETURN NULL;
+END;
+$vote_delete$ LANGUAGE  plpgsql;
+
+DROP TRIGGER IF EXISTS vote_delete ON Thread_vote;
+CREATE TRIGGER vote_delete AFTER DELETE ON Thread_vote FOR EACH ROW EXECUTE PROCEDURE vote_delete();
+
 CREATE OR REPLACE FUNCTION set_post_path()
     RETURNS TRIGGER AS
 $set_post_path$
@@ -240,5 +346,560 @@ CREATE TRIGGER set_post_path
     FOR EACH ROW
     EXECUTE PROCEDURE set_post_path();
 
+CREATE UNLOGGED TABLE IF NOT EXISTS notifications (
+    id       SERIAL PRIMARY KEY,
+    nickname CITEXT  NOT NULL REFERENCES users(nickname) ON DELETE CASCADE,
+    type     TEXT    NOT NULL,
+    actor    CITEXT  NOT NULL,
+    forum    CITEXT  NOT NULL,
+    thread   INT     NOT NULL DEFAULT 0,
+    post     INT     NOT NULL DEFAULT 0,
+    created  TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    is_read  BOOLEAN NOT NULL DEFAULT FALSE
+);
+
+CREATE INDEX index_notifications_nickname_id ON notifications (nickname, id);
+CREATE INDEX index_notifications_nickname_unread ON notifications (nickname) WHERE NOT is_read;
+
+-- ignoring is one-sided and silent: the ignoring user sees the ignored user's posts collapsed, gets no
+-- notifications, feed items or messages from them, while the ignored user notices nothing but a refused message.
+-- user_blocks is the mutual, messaging-only counterpart
+CREATE UNLOGGED TABLE IF NOT EXISTS user_ignores (
+    nickname CITEXT NOT NULL REFERENCES users(nickname),
+    ignored  CITEXT NOT NULL REFERENCES users(nickname),
+    created  TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    PRIMARY KEY (nickname, ignored)
+);
+
+CREATE OR REPLACE FUNCTION notify_post_insert()
+    RETURNS TRIGGER AS $notify_post_insert$
+BEGIN
+INSERT INTO notifications (nickname, type, actor, forum, thread, post)
+SELECT p.author, 'reply', NEW.author, NEW.forum, NEW.thread, NEW.id FROM posts AS p
+WHERE p.id = NEW.parent AND p.author <> NEW.author
+    AND NOT EXISTS (SELECT 1 FROM user_ignores WHERE nickname = p.author AND ignored = NEW.author);
+
+INSERT INTO notifications (nickname, type, actor, forum, thread, post)
+SELECT DISTINCT u.nickname, 'mention', NEW.author, NEW.forum, NEW.thread, NEW.id
+FROM regexp_matches(NEW.msg, '@([A-Za-z0-9_.]+)', 'g') AS m(mention)
+JOIN users AS u ON u.nickname = rtrim(m.mention[1], '.')::citext
+WHERE u.nickname <> NEW.author
+    AND NOT EXISTS (SELECT 1 FROM user_ignores WHERE nickname = u.nickname AND ignored = NEW.author);
+RETURN NULL;
+END;
+$notify_post_insert$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS notify_post_insert ON posts;
+CREATE TRIGGER notify_post_insert AFTER INSERT ON posts FOR EACH ROW EXECUTE PROCEDURE notify_post_insert();
+
+CREATE OR REPLACE FUNCTION notify_thread_insert()
+    RETURNS TRIGGER AS $notify_thread_insert$
+BEGIN
+INSERT INTO notifications (nickname, type, actor, forum, thread)
+SELECT DISTINCT u.nickname, 'mention', NEW.author, NEW.forum, NEW.id
+FROM regexp_matches(NEW.msg, '@([A-Za-z0-9_.]+)', 'g') AS m(mention)
+JOIN users AS u ON u.nickname = rtrim(m.mention[1], '.')::citext
+WHERE u.nickname <> NEW.author
+    AND NOT EXISTS (SELECT 1 FROM user_ignores WHERE nickname = u.nickname AND ignored = NEW.author);
+RETURN NULL;
+END;
+$notify_thread_insert$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS notify_thread_insert ON threads;
+CREATE TRIGGER notify_thread_insert AFTER INSERT ON threads FOR EACH ROW EXECUTE PROCEDURE notify_thread_insert();
+
+CREATE OR REPLACE FUNCTION notify_thread_vote()
+    RETURNS TRIGGER AS $notify_thread_vote$
+BEGIN
+INSERT INTO notifications (nickname, type, actor, forum, thread)
+SELECT t.author, 'vote', NEW.nickname, t.forum, t.id FROM threads AS t
+WHERE t.id = NEW.thread_id AND t.author <> NEW.nickname
+    AND NOT EXISTS (SELECT 1 FROM user_ignores WHERE nickname = t.author AND ignored = NEW.nickname);
+RETURN NULL;
+END;
+$notify_thread_vote$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS notify_thread_vote_insert ON Thread_vote;
+CREATE TRIGGER notify_thread_vote_insert AFTER INSERT ON Thread_vote FOR EACH ROW EXECUTE PROCEDURE notify_thread_vote();
+
+DROP TRIGGER IF EXISTS notify_thread_vote_update ON Thread_vote;
+CREATE TRIGGER notify_thread_vote_update AFTER UPDATE ON Thread_vote
+    FOR EACH ROW WHEN (OLD.vote IS DISTINCT FROM NEW.vote) EXECUTE PROCEDURE notify_thread_vote();
+
+CREATE OR REPLACE FUNCTION notify_thread_posts()
+    RETURNS TRIGGER AS $notify_thread_posts$
+BEGIN
+PERFORM pg_notify('thread_posts', thread::TEXT) FROM (SELECT DISTINCT thread FROM new_posts) AS t;
+RETURN NULL;
+END;
+$notify_thread_posts$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS notify_thread_posts ON posts;
+CREATE TRIGGER notify_thread_posts AFTER INSERT ON posts
+    REFERENCING NEW TABLE AS new_posts
+    FOR EACH STATEMENT EXECUTE PROCEDURE notify_thread_posts();
+
+CREATE OR REPLACE FUNCTION notify_forum_event(event_type TEXT, event_forum CITEXT, event_thread INT, event_post INT)
+    RETURNS VOID AS $notify_forum_event$
+BEGIN
+PERFORM pg_notify('forum_events', json_build_object(
+    'type', event_type, 'forum', event_forum, 'threadId', event_thread, 'postId', event_post)::TEXT);
+END;
+$notify_forum_event$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION forum_event_post()
+    RETURNS TRIGGER AS $forum_event_post$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM notify_forum_event('post.created', NEW.forum, NEW.thread, NEW.id);
+    ELSIF NEW.msg IS DISTINCT FROM OLD.msg THEN
+        PERFORM notify_forum_event('post.edited', NEW.forum, NEW.thread, NEW.id);
+END IF;
+RETURN NULL;
+END;
+$forum_event_post$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS forum_event_post ON posts;
+CREATE TRIGGER forum_event_post AFTER INSERT OR UPDATE ON posts FOR EACH ROW EXECUTE PROCEDURE forum_event_post();
+
+CREATE OR REPLACE FUNCTION forum_event_thread()
+    RETURNS TRIGGER AS $forum_event_thread$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        PERFORM notify_forum_event('thread.created', NEW.forum, NEW.id, 0);
+    ELSIF NEW.title IS DISTINCT FROM OLD.title OR NEW.msg IS DISTINCT FROM OLD.msg THEN
+        PERFORM notify_forum_event('thread.updated', NEW.forum, NEW.id, 0);
+    ELSIF NEW.votes IS DISTINCT FROM OLD.votes THEN
+        PERFORM notify_forum_event('thread.voted', NEW.forum, NEW.id, 0);
+END IF;
+RETURN NULL;
+END;
+$forum_event_thread$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS forum_event_thread ON threads;
+CREATE TRIGGER forum_event_thread AFTER INSERT OR UPDATE ON threads FOR EACH ROW EXECUTE PROCEDURE forum_event_thread();
+
+CREATE INDEX index_posts_search ON posts USING GIN (search);
+CREATE INDEX index_threads_search ON threads USING GIN (search);
+
+CREATE OR REPLACE FUNCTION posts_search_update()
+    RETURNS TRIGGER AS $posts_search_update$
+BEGIN
+    NEW.search = to_tsvector('english', NEW.msg);
+RETURN NEW;
+END;
+$posts_search_update$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS posts_search_update ON posts;
+CREATE TRIGGER posts_search_update BEFORE INSERT OR UPDATE OF msg ON posts
+    FOR EACH ROW EXECUTE PROCEDURE posts_search_update();
+
+CREATE OR REPLACE FUNCTION threads_search_update()
+    RETURNS TRIGGER AS $threads_search_update$
+BEGIN
+    NEW.search = setweight(to_tsvector('english', NEW.title), 'A') ||
+                 setweight(to_tsvector('english', NEW.msg), 'B');
+RETURN NEW;
+END;
+$threads_search_update$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS threads_search_update ON threads;
+CREATE TRIGGER threads_search_update BEFORE INSERT OR UPDATE OF title, msg ON threads
+    FOR EACH ROW EXECUTE PROCEDURE threads_search_update();
+
+-- members have no row; admin is global, moderators are per forum.
+-- banned is not stored here, GetRole reports it from an active global ban in bans
+CREATE UNLOGGED TABLE global_roles (
+    nickname CITEXT NOT NULL PRIMARY KEY REFERENCES users(nickname) ON DELETE CASCADE,
+    role     TEXT   NOT NULL CHECK (role IN ('admin'))
+);
+
+CREATE UNLOGGED TABLE forum_moderators (
+    forum_slug CITEXT NOT NULL REFERENCES forums(slug) ON DELETE CASCADE,
+    nickname   CITEXT NOT NULL REFERENCES users(nickname) ON DELETE CASCADE,
+    PRIMARY KEY (forum_slug, nickname)
+);
+
+CREATE OR REPLACE FUNCTION forum_owner_moderator()
+    RETURNS TRIGGER AS $forum_owner_moderator$
+BEGIN
+INSERT INTO forum_moderators (forum_slug, nickname) VALUES (NEW.slug, NEW.user_nickname)
+    ON CONFLICT DO NOTHING;
+RETURN NULL;
+END;
+$forum_owner_moderator$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS forum_owner_moderator ON forums;
+CREATE TRIGGER forum_owner_moderator AFTER INSERT ON forums FOR EACH ROW EXECUTE PROCEDURE forum_owner_moderator();
+
+CREATE OR REPLACE FUNCTION posts_deleted_counter()
+    RETURNS TRIGGER AS $posts_deleted_counter$
+BEGIN
+UPDATE forums
+SET post_count = post_count + CASE WHEN NEW.isDeleted THEN -1 ELSE 1 END
+WHERE slug = NEW.forum;
+RETURN NULL;
+END;
+$posts_deleted_counter$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS posts_deleted_counter ON posts;
+CREATE TRIGGER posts_deleted_counter AFTER UPDATE OF isDeleted ON posts
+    FOR EACH ROW WHEN (OLD.isDeleted IS DISTINCT FROM NEW.isDeleted)
+    EXECUTE PROCEDURE posts_deleted_counter();
+
+CREATE UNLOGGED TABLE post_revisions (
+    id SERIAL PRIMARY KEY,
+    post INTEGER NOT NULL REFERENCES posts(id),
+    revision INTEGER NOT NULL,
+    editor CITEXT NOT NULL REFERENCES users(nickname),
+    msg TEXT NOT NULL,
+    created TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    UNIQUE (post, revision)
+);
+
+CREATE UNLOGGED TABLE bans (
+    id        SERIAL PRIMARY KEY,
+    nickname  CITEXT NOT NULL REFERENCES users(nickname) ON DELETE CASCADE,
+    forum     CITEXT REFERENCES forums(slug) ON DELETE CASCADE,
+    reason    TEXT   NOT NULL DEFAULT '',
+    issued_by CITEXT NOT NULL REFERENCES users(nickname),
+    created   TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    expires   TIMESTAMP WITH TIME ZONE,
+    lifted    TIMESTAMP WITH TIME ZONE,
+    lifted_by CITEXT REFERENCES users(nickname)
+);
+
+CREATE INDEX index_bans_active ON bans (nickname, forum) WHERE lifted IS NULL;
+
+CREATE UNLOGGED TABLE report_resolutions (
+    id          SERIAL PRIMARY KEY,
+    target_type TEXT   NOT NULL,
+    target_id   INT    NOT NULL,
+    forum       CITEXT NOT NULL,
+    action      TEXT   NOT NULL CHECK (action IN ('dismiss', 'hide_post', 'lock_thread', 'ban_author')),
+    note        TEXT   NOT NULL DEFAULT '',
+    moderator   CITEXT NOT NULL REFERENCES users(nickname),
+    reports     INT    NOT NULL,
+    created     TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE UNLOGGED TABLE reports (
+    id          SERIAL PRIMARY KEY,
+    target_type TEXT   NOT NULL CHECK (target_type IN ('post', 'thread')),
+    target_id   INT    NOT NULL,
+    forum       CITEXT NOT NULL REFERENCES forums(slug) ON DELETE CASCADE,
+    author      CITEXT NOT NULL,
+    reporter    CITEXT NOT NULL REFERENCES users(nickname) ON DELETE CASCADE,
+    reason      TEXT   NOT NULL CHECK (reason IN ('spam', 'abuse', 'off-topic', 'illegal', 'other')),
+    comment     TEXT   NOT NULL DEFAULT '',
+    created     TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    resolution  INT    REFERENCES report_resolutions(id)
+);
+
+CREATE UNIQUE INDEX index_reports_open_reporter ON reports (target_type, target_id, reporter) WHERE resolution IS NULL;
+CREATE INDEX index_reports_open_forum ON reports (forum) WHERE resolution IS NULL;
+
+-- audit_log has no foreign keys so it outlives /api/service/clear, and refuses to be changed;
+-- unlike the rest it is logged, a crash must not truncate it
+CREATE TABLE audit_log (
+    id          SERIAL PRIMARY KEY,
+    actor       CITEXT NOT NULL,
+    action      TEXT   NOT NULL,
+    target_type TEXT   NOT NULL,
+    target      TEXT   NOT NULL,
+    forum       CITEXT,
+    before      JSONB,
+    after       JSONB,
+    created     TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE INDEX index_audit_log_actor ON audit_log (actor, id);
+CREATE INDEX index_audit_log_forum ON audit_log (forum, id);
+CREATE INDEX index_audit_log_created ON audit_log (created);
+
+CREATE OR REPLACE FUNCTION audit_log_append_only()
+    RETURNS TRIGGER AS $audit_log_append_only$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$audit_log_append_only$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS audit_log_append_only ON audit_log;
+CREATE TRIGGER audit_log_append_only BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE PROCEDURE audit_log_append_only();
+
+DROP TRIGGER IF EXISTS audit_log_no_truncate ON audit_log;
+CREATE TRIGGER audit_log_no_truncate BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE PROCEDURE audit_log_append_only();
+
+-- posts.reactions keeps the per-reaction counts, so post listings read them without aggregating
+CREATE UNLOGGED TABLE post_reaction (
+    post     INTEGER NOT NULL REFERENCES posts(id),
+    nickname CITEXT NOT NULL REFERENCES users(nickname),
+    reaction TEXT NOT NULL,
+    created  TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    PRIMARY KEY (post, nickname, reaction)
+);
+
+CREATE OR REPLACE FUNCTION post_reaction_counter()
+    RETURNS TRIGGER AS $post_reaction_counter$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE posts
+        SET reactions = jsonb_set(reactions, ARRAY[NEW.reaction],
+            to_jsonb(COALESCE((reactions ->> NEW.reaction)::INTEGER, 0) + 1))
+        WHERE id = NEW.post;
+    ELSE
+        UPDATE posts
+        SET reactions = CASE WHEN COALESCE((reactions ->> OLD.reaction)::INTEGER, 0) <= 1
+            THEN reactions - OLD.reaction
+            ELSE jsonb_set(reactions, ARRAY[OLD.reaction], to_jsonb((reactions ->> OLD.reaction)::INTEGER - 1))
+        END
+        WHERE id = OLD.post;
+END IF;
+RETURN NULL;
+END;
+$post_reaction_counter$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS post_reaction_counter ON post_reaction;
+CREATE TRIGGER post_reaction_counter AFTER INSERT OR DELETE ON post_reaction
+    FOR EACH ROW EXECUTE PROCEDURE post_reaction_counter();
+
+CREATE UNLOGGED TABLE IF NOT EXISTS Post_vote (
+    nickname CITEXT REFERENCES users(nickname) NOT NULL,
+    post_id  INT REFERENCES posts(id)          NOT NULL,
+    vote     INT                               NOT NULL CHECK (vote IN (-1, 0, 1))
+);
+
+ALTER TABLE ONLY Post_vote ADD CONSTRAINT votes_user_post_unique UNIQUE (nickname, post_id);
+CLUSTER Post_vote USING votes_user_post_unique;
+
+CREATE OR REPLACE FUNCTION post_vote_insert()
+    RETURNS TRIGGER AS $post_vote_insert$
+BEGIN
+UPDATE posts
+SET votes = votes + NEW.vote
+WHERE id = NEW.post_id;
+RETURN NULL;
+END;
+$post_vote_insert$  LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS post_vote_insert ON Post_vote;
+CREATE TRIGGER post_vote_insert AFTER INSERT ON Post_vote FOR EACH ROW EXECUTE PROCEDURE post_vote_insert();
+
+-- a voice of 0 retracts the vote, so the score moves by the difference rather than by a fixed step
+CREATE OR REPLACE FUNCTION post_vote_update() RETURNS TRIGGER AS $post_vote_update$
+BEGIN
+    IF OLD.vote = NEW.vote
+    THEN
+        RETURN NULL;
+END IF;
+UPDATE posts
+SET votes = votes + NEW.vote - OLD.vote
+WHERE id = NEW.post_id;
+RETURN NULL;
+END;
+$post_vote_update$ LANGUAGE  plpgsql;
+
+DROP TRIGGER IF EXISTS post_vote_update ON Post_vote;
+CREATE TRIGGER post_vote_update AFTER UPDATE ON Post_vote FOR EACH ROW EXECUTE PROCEDURE post_vote_update();
+
+-- karma of a user is the sum of the votes on their threads and posts
+CREATE OR REPLACE FUNCTION karma_thread_vote()
+    RETURNS TRIGGER AS $karma_thread_vote$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE users SET karma = karma + NEW.vote
+        WHERE nickname = (SELECT author FROM threads WHERE id = NEW.thread_id);
+    ELSIF TG_OP = 'UPDATE' THEN
+        UPDATE users SET karma = karma + NEW.vote - OLD.vote
+        WHERE nickname = (SELECT author FROM threads WHERE id = NEW.thread_id);
+    ELSE
+        UPDATE users SET karma = karma - OLD.vote
+        WHERE nickname = (SELECT author FROM threads WHERE id = OLD.thread_id);
+END IF;
+RETURN NULL;
+END;
+$karma_thread_vote$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS karma_thread_vote ON Thread_vote;
+CREATE TRIGGER karma_thread_vote AFTER INSERT OR UPDATE OR DELETE ON Thread_vote
+    FOR EACH ROW EXECUTE PROCEDURE karma_thread_vote();
+
+CREATE OR REPLACE FUNCTION karma_post_vote()
+    RETURNS TRIGGER AS $karma_post_vote$
+BEGIN
+    IF TG_OP = 'INSERT' THEN
+        UPDATE users SET karma = karma + NEW.vote
+        WHERE nickname = (SELECT author FROM posts WHERE id = NEW.post_id);
+    ELSIF TG_OP = 'UPDATE' THEN
+        UPDATE users SET karma = karma + NEW.vote - OLD.vote
+        WHERE nickname = (SELECT author FROM posts WHERE id = NEW.post_id);
+    ELSE
+        UPDATE users SET karma = karma - OLD.vote
+        WHERE nickname = (SELECT author FROM posts WHERE id = OLD.post_id);
+END IF;
+RETURN NULL;
+END;
+$karma_post_vote$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS karma_post_vote ON Post_vote;
+CREATE TRIGGER karma_post_vote AFTER INSERT OR UPDATE OR DELETE ON Post_vote
+    FOR EACH ROW EXECUTE PROCEDURE karma_post_vote();
+
+CREATE INDEX index_users_karma ON users (karma, nickname);
+
+CREATE UNLOGGED TABLE thread_subscriptions (
+    nickname  CITEXT NOT NULL REFERENCES users(nickname),
+    thread_id INT NOT NULL REFERENCES threads(id),
+    created   TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    PRIMARY KEY (nickname, thread_id)
+);
+
+-- threads up to last_read_thread existed when the user subscribed, later ones leave the feed through read_markers once served
+CREATE UNLOGGED TABLE forum_subscriptions (
+    nickname         CITEXT NOT NULL REFERENCES users(nickname),
+    forum_slug       CITEXT NOT NULL REFERENCES forums(slug),
+    last_read_thread INT NOT NULL DEFAULT 0,
+    created          TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    PRIMARY KEY (nickname, forum_slug)
+);
+
+-- posts of the thread with an id above last_read_post are unread, counted with index_posts_thread_id
+CREATE UNLOGGED TABLE read_markers (
+    nickname       CITEXT NOT NULL REFERENCES users(nickname),
+    thread_id      INT NOT NULL REFERENCES threads(id),
+    last_read_post INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (nickname, thread_id)
+);
+
+CREATE INDEX index_forum_subscriptions_forum ON forum_subscriptions (forum_slug);
+CREATE INDEX index_thread_subscriptions_thread ON thread_subscriptions (thread_id);
+
+-- last_message is kept by conversation_last_message, conversations are listed and paged by it
+-- member_low and member_high are the two members in citext order, so a pair can only ever have one conversation
+CREATE UNLOGGED TABLE conversations (
+    id           SERIAL PRIMARY KEY,
+    member_low   CITEXT NOT NULL,
+    member_high  CITEXT NOT NULL,
+    created      TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    last_message INT NOT NULL DEFAULT 0,
+    UNIQUE (member_low, member_high)
+);
+
+CREATE UNLOGGED TABLE conversation_members (
+    conversation_id INT NOT NULL REFERENCES conversations(id),
+    nickname        CITEXT NOT NULL REFERENCES users(nickname),
+    PRIMARY KEY (conversation_id, nickname)
+);
+
+CREATE INDEX index_conversation_members_nickname ON conversation_members (nickname, conversation_id);
+
+CREATE UNLOGGED TABLE messages (
+    id              SERIAL PRIMARY KEY,
+    conversation_id INT NOT NULL REFERENCES conversations(id),
+    author          CITEXT NOT NULL REFERENCES users(nickname),
+    msg             TEXT NOT NULL,
+    created         TIMESTAMP WITH TIME ZONE DEFAULT now()
+);
+
+CREATE INDEX index_messages_conversation_id ON messages (conversation_id, id);
+
+CREATE OR REPLACE FUNCTION conversation_last_message()
+    RETURNS TRIGGER AS $conversation_last_message$
+BEGIN
+UPDATE conversations
+SET last_message = NEW.id
+WHERE id = NEW.conversation_id;
+RETURN NULL;
+END;
+$conversation_last_message$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS conversation_last_message ON messages;
+CREATE TRIGGER conversation_last_message AFTER INSERT ON messages FOR EACH ROW EXECUTE PROCEDURE conversation_last_message();
+
+-- a block in either direction stops private messages between the two users and leaves content visible,
+-- user_ignores is the one-sided variant that also hides the ignored user's content from the ignoring user
+CREATE UNLOGGED TABLE user_blocks (
+    nickname CITEXT NOT NULL REFERENCES users(nickname),
+    blocked  CITEXT NOT NULL REFERENCES users(nickname),
+    created  TIMESTAMP WITH TIME ZONE DEFAULT now(),
+    PRIMARY KEY (nickname, blocked)
+);
+
+-- tags are stored lowercased on the thread, forum_tags keeps the per-forum counts for autocomplete
+ALTER TABLE threads ADD COLUMN IF NOT EXISTS tags TEXT[] NOT NULL DEFAULT '{}';
+
+-- a thread moved with a redirect leaves a locked stub in its old forum, moved_to points at the moved thread
+ALTER TABLE threads ADD COLUMN IF NOT EXISTS moved_to INT;
+
+CREATE INDEX IF NOT EXISTS index_threads_moved_to ON threads (moved_to) WHERE moved_to IS NOT NULL;
+
+-- a stub is not a thread of its forum: it stays out of thread_count and does not make its author a forum user
+DROP TRIGGER IF EXISTS threads_forum_counter ON threads;
+CREATE TRIGGER threads_forum_counter AFTER INSERT ON threads
+    FOR EACH ROW WHEN (NEW.moved_to IS NULL) EXECUTE PROCEDURE threads_forum_counter();
+
+DROP TRIGGER IF EXISTS add_forum_user_new_thread ON threads;
+CREATE TRIGGER add_forum_user_new_thread AFTER INSERT ON threads
+    FOR EACH ROW WHEN (NEW.moved_to IS NULL) EXECUTE PROCEDURE add_forum_user_thread();
+
+-- nor does it mention anyone, announce itself to the forum or turn up in search
+DROP TRIGGER IF EXISTS notify_thread_insert ON threads;
+CREATE TRIGGER notify_thread_insert AFTER INSERT ON threads
+    FOR EACH ROW WHEN (NEW.moved_to IS NULL) EXECUTE PROCEDURE notify_thread_insert();
+
+DROP TRIGGER IF EXISTS forum_event_thread ON threads;
+CREATE TRIGGER forum_event_thread AFTER INSERT OR UPDATE ON threads
+    FOR EACH ROW WHEN (NEW.moved_to IS NULL) EXECUTE PROCEDURE forum_event_thread();
+
+DROP TRIGGER IF EXISTS threads_search_update ON threads;
+CREATE TRIGGER threads_search_update BEFORE INSERT OR UPDATE OF title, msg ON threads
+    FOR EACH ROW WHEN (NEW.moved_to IS NULL) EXECUTE PROCEDURE threads_search_update();
+
+CREATE INDEX IF NOT EXISTS index_threads_tags ON threads USING GIN (tags);
+
+CREATE UNLOGGED TABLE forum_tags (
+    forum        CITEXT NOT NULL,
+    tag          TEXT NOT NULL,
+    thread_count INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (forum, tag)
+);
+
+CREATE INDEX index_forum_tags_tag ON forum_tags (tag text_pattern_ops);
+
+CREATE OR REPLACE FUNCTION forum_tags_counter()
+    RETURNS TRIGGER AS $forum_tags_counter$
+BEGIN
+IF TG_OP IN ('UPDATE', 'DELETE') THEN
+    UPDATE forum_tags SET thread_count = thread_count - 1
+    WHERE forum = OLD.forum AND tag = ANY(OLD.tags);
+
+    DELETE FROM forum_tags WHERE forum = OLD.forum AND thread_count <= 0;
+END IF;
+
+IF TG_OP IN ('INSERT', 'UPDATE') THEN
+    INSERT INTO forum_tags (forum, tag, thread_count)
+    SELECT NEW.forum, tag, 1 FROM unnest(NEW.tags) AS tag
+    ON CONFLICT (forum, tag) DO UPDATE SET thread_count = forum_tags.thread_count + 1;
+END IF;
+RETURN NULL;
+END;
+$forum_tags_counter$ LANGUAGE plpgsql;
+
+-- redirect stubs carry no tags of their own
+DROP TRIGGER IF EXISTS forum_tags_insert ON threads;
+CREATE TRIGGER forum_tags_insert AFTER INSERT ON threads
+    FOR EACH ROW WHEN (NEW.moved_to IS NULL) EXECUTE PROCEDURE forum_tags_counter();
+
+DROP TRIGGER IF EXISTS forum_tags_delete ON threads;
+CREATE TRIGGER forum_tags_delete AFTER DELETE ON threads
+    FOR EACH ROW WHEN (OLD.moved_to IS NULL) EXECUTE PROCEDURE forum_tags_counter();
+
+DROP TRIGGER IF EXISTS forum_tags_update ON threads;
+CREATE TRIGGER forum_tags_update AFTER UPDATE OF tags, forum ON threads
+    FOR EACH ROW WHEN (OLD.tags IS DISTINCT FROM NEW.tags OR OLD.forum IS DISTINCT FROM NEW.forum)
+    EXECUTE PROCEDURE forum_tags_counter();
+
 VACUUM;
 VACUUM ANALYSE;
\ No newline at end of file
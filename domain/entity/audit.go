@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+)
+
+const AuditThreadUpdate = "thread.update"
+const AuditThreadState = "thread.state"
+const AuditThreadDelete = "thread.delete"
+const AuditThreadMove = "thread.move"
+const AuditPostEdit = "post.edit"
+const AuditPostDelete = "post.delete"
+const AuditPostRestore = "post.restore"
+const AuditForumDelete = "forum.delete"
+const AuditModeratorGrant = "moderator.grant"
+const AuditModeratorRevoke = "moderator.revoke"
+const AuditBanCreate = "ban.create"
+const AuditBanLift = "ban.lift"
+const AuditReportResolve = "report.resolve"
+const AuditServiceClear = "service.clear"
+
+const AuditTargetThread = "thread"
+const AuditTargetPost = "post"
+const AuditTargetForum = "forum"
+const AuditTargetUser = "user"
+const AuditTargetBan = "ban"
+const AuditTargetService = "service"
+
+// AuditAnonymousActor stands for requests made without a session
+const AuditAnonymousActor = "anonymous"
+
+// AuditEntry is one row of the append-only audit log; Before and After hold the target as JSON
+type AuditEntry struct {
+	ID         int             `json:"id"`
+	Actor      string          `json:"actor"`
+	Action     string          `json:"action"`
+	TargetType string          `json:"targetType"`
+	Target     string          `json:"target"`
+	Forum      string          `json:"forum,omitempty"`
+	Before     json.RawMessage `json:"before,omitempty"`
+	After      json.RawMessage `json:"after,omitempty"`
+	Created    strfmt.DateTime `json:"created"`
+}
+
+type AuditLog struct {
+	Entries []AuditEntry `json:"entries"`
+	Cursor  string       `json:"cursor,omitempty"`
+}
+
+//easyjson:skip
+type AuditQuery struct {
+	Actor   string
+	Forum   string
+	Action  string
+	Since   time.Time
+	Until   time.Time
+	Limit   int32
+	AfterID int
+}
@@ -0,0 +1,252 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package entity
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonF2c44427DecodeForumDomainEntity(in *jlexer.Lexer, out *AuditLog) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "entries":
+			if in.IsNull() {
+				in.Skip()
+				out.Entries = nil
+			} else {
+				in.Delim('[')
+				if out.Entries == nil {
+					if !in.IsDelim(']') {
+						out.Entries = make([]AuditEntry, 0, 0)
+					} else {
+						out.Entries = []AuditEntry{}
+					}
+				} else {
+					out.Entries = (out.Entries)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 AuditEntry
+					(v1).UnmarshalEasyJSON(in)
+					out.Entries = append(out.Entries, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "cursor":
+			out.Cursor = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF2c44427EncodeForumDomainEntity(out *jwriter.Writer, in AuditLog) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"entries\":"
+		out.RawString(prefix[1:])
+		if in.Entries == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Entries {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				(v3).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Cursor != "" {
+		const prefix string = ",\"cursor\":"
+		out.RawString(prefix)
+		out.String(string(in.Cursor))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuditLog) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF2c44427EncodeForumDomainEntity(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuditLog) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF2c44427EncodeForumDomainEntity(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuditLog) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF2c44427DecodeForumDomainEntity(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuditLog) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF2c44427DecodeForumDomainEntity(l, v)
+}
+func easyjsonF2c44427DecodeForumDomainEntity1(in *jlexer.Lexer, out *AuditEntry) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "actor":
+			out.Actor = string(in.String())
+		case "action":
+			out.Action = string(in.String())
+		case "targetType":
+			out.TargetType = string(in.String())
+		case "target":
+			out.Target = string(in.String())
+		case "forum":
+			out.Forum = string(in.String())
+		case "before":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Before).UnmarshalJSON(data))
+			}
+		case "after":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.After).UnmarshalJSON(data))
+			}
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonF2c44427EncodeForumDomainEntity1(out *jwriter.Writer, in AuditEntry) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"actor\":"
+		out.RawString(prefix)
+		out.String(string(in.Actor))
+	}
+	{
+		const prefix string = ",\"action\":"
+		out.RawString(prefix)
+		out.String(string(in.Action))
+	}
+	{
+		const prefix string = ",\"targetType\":"
+		out.RawString(prefix)
+		out.String(string(in.TargetType))
+	}
+	{
+		const prefix string = ",\"target\":"
+		out.RawString(prefix)
+		out.String(string(in.Target))
+	}
+	if in.Forum != "" {
+		const prefix string = ",\"forum\":"
+		out.RawString(prefix)
+		out.String(string(in.Forum))
+	}
+	if len(in.Before) != 0 {
+		const prefix string = ",\"before\":"
+		out.RawString(prefix)
+		out.Raw((in.Before).MarshalJSON())
+	}
+	if len(in.After) != 0 {
+		const prefix string = ",\"after\":"
+		out.RawString(prefix)
+		out.Raw((in.After).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.Raw((in.Created).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v AuditEntry) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonF2c44427EncodeForumDomainEntity1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v AuditEntry) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonF2c44427EncodeForumDomainEntity1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *AuditEntry) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonF2c44427DecodeForumDomainEntity1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *AuditEntry) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonF2c44427DecodeForumDomainEntity1(l, v)
+}
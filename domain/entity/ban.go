@@ -0,0 +1,20 @@
+package entity
+
+import "github.com/go-openapi/strfmt"
+
+// Ban without a forum is global; with a forum it mutes the user there only.
+// Expires and Lifted stay empty while the ban is open-ended and in force
+type Ban struct {
+	ID       int              `json:"id"`
+	Nickname string           `json:"nickname"`
+	Forum    string           `json:"forum,omitempty"`
+	Reason   string           `json:"reason"`
+	IssuedBy string           `json:"issuedBy"`
+	Created  strfmt.DateTime  `json:"created,omitempty"`
+	Expires  *strfmt.DateTime `json:"expires,omitempty"`
+	Lifted   *strfmt.DateTime `json:"lifted,omitempty"`
+	LiftedBy string           `json:"liftedBy,omitempty"`
+}
+
+//easyjson:json
+type Bans []Ban
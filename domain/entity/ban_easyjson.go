@@ -0,0 +1,230 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package entity
+
+import (
+	json "encoding/json"
+	strfmt "github.com/go-openapi/strfmt"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjson2452dbc5DecodeForumDomainEntity(in *jlexer.Lexer, out *Bans) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Bans, 0, 0)
+			} else {
+				*out = Bans{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 Ban
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2452dbc5EncodeForumDomainEntity(out *jwriter.Writer, in Bans) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Bans) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2452dbc5EncodeForumDomainEntity(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Bans) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2452dbc5EncodeForumDomainEntity(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Bans) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2452dbc5DecodeForumDomainEntity(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Bans) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2452dbc5DecodeForumDomainEntity(l, v)
+}
+func easyjson2452dbc5DecodeForumDomainEntity1(in *jlexer.Lexer, out *Ban) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "forum":
+			out.Forum = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		case "issuedBy":
+			out.IssuedBy = string(in.String())
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		case "expires":
+			if in.IsNull() {
+				in.Skip()
+				out.Expires = nil
+			} else {
+				if out.Expires == nil {
+					out.Expires = new(strfmt.DateTime)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.Expires).UnmarshalJSON(data))
+				}
+			}
+		case "lifted":
+			if in.IsNull() {
+				in.Skip()
+				out.Lifted = nil
+			} else {
+				if out.Lifted == nil {
+					out.Lifted = new(strfmt.DateTime)
+				}
+				if data := in.Raw(); in.Ok() {
+					in.AddError((*out.Lifted).UnmarshalJSON(data))
+				}
+			}
+		case "liftedBy":
+			out.LiftedBy = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson2452dbc5EncodeForumDomainEntity1(out *jwriter.Writer, in Ban) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"nickname\":"
+		out.RawString(prefix)
+		out.String(string(in.Nickname))
+	}
+	if in.Forum != "" {
+		const prefix string = ",\"forum\":"
+		out.RawString(prefix)
+		out.String(string(in.Forum))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	{
+		const prefix string = ",\"issuedBy\":"
+		out.RawString(prefix)
+		out.String(string(in.IssuedBy))
+	}
+	if true {
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.Raw((in.Created).MarshalJSON())
+	}
+	if in.Expires != nil {
+		const prefix string = ",\"expires\":"
+		out.RawString(prefix)
+		out.Raw((*in.Expires).MarshalJSON())
+	}
+	if in.Lifted != nil {
+		const prefix string = ",\"lifted\":"
+		out.RawString(prefix)
+		out.Raw((*in.Lifted).MarshalJSON())
+	}
+	if in.LiftedBy != "" {
+		const prefix string = ",\"liftedBy\":"
+		out.RawString(prefix)
+		out.String(string(in.LiftedBy))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Ban) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson2452dbc5EncodeForumDomainEntity1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Ban) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson2452dbc5EncodeForumDomainEntity1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Ban) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson2452dbc5DecodeForumDomainEntity1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Ban) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson2452dbc5DecodeForumDomainEntity1(l, v)
+}
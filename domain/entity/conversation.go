@@ -0,0 +1,41 @@
+package entity
+
+import "github.com/go-openapi/strfmt"
+
+// Conversation is a private thread between two users; LastMessage is unset until the first message
+type Conversation struct {
+	ID          int             `json:"id"`
+	Members     []string        `json:"members"`
+	Created     strfmt.DateTime `json:"created"`
+	LastMessage *PrivateMessage `json:"lastMessage,omitempty"`
+}
+
+//easyjson:json
+type Conversations []Conversation
+
+type PrivateMessage struct {
+	ID           int             `json:"id"`
+	Conversation int             `json:"conversation"`
+	Author       string          `json:"author"`
+	Message      string          `json:"message"`
+	Created      strfmt.DateTime `json:"created"`
+}
+
+//easyjson:json
+type PrivateMessages []PrivateMessage
+
+// ConversationInput starts or reopens the conversation with the user With, Message is optional
+type ConversationInput struct {
+	With    string `json:"with"`
+	Message string `json:"message,omitempty"`
+}
+
+type PrivateMessageInput struct {
+	Message string `json:"message"`
+}
+
+//easyjson:json
+type BlockedUsers []string
+
+//easyjson:json
+type IgnoredUsers []string
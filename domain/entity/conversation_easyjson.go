@@ -0,0 +1,647 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package entity
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonA5648bb1DecodeForumDomainEntity(in *jlexer.Lexer, out *PrivateMessages) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(PrivateMessages, 0, 0)
+			} else {
+				*out = PrivateMessages{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 PrivateMessage
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA5648bb1EncodeForumDomainEntity(out *jwriter.Writer, in PrivateMessages) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PrivateMessages) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA5648bb1EncodeForumDomainEntity(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PrivateMessages) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA5648bb1EncodeForumDomainEntity(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PrivateMessages) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA5648bb1DecodeForumDomainEntity(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PrivateMessages) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA5648bb1DecodeForumDomainEntity(l, v)
+}
+func easyjsonA5648bb1DecodeForumDomainEntity1(in *jlexer.Lexer, out *PrivateMessageInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "message":
+			out.Message = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA5648bb1EncodeForumDomainEntity1(out *jwriter.Writer, in PrivateMessageInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Message))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PrivateMessageInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA5648bb1EncodeForumDomainEntity1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PrivateMessageInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA5648bb1EncodeForumDomainEntity1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PrivateMessageInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA5648bb1DecodeForumDomainEntity1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PrivateMessageInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA5648bb1DecodeForumDomainEntity1(l, v)
+}
+func easyjsonA5648bb1DecodeForumDomainEntity2(in *jlexer.Lexer, out *PrivateMessage) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "conversation":
+			out.Conversation = int(in.Int())
+		case "author":
+			out.Author = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA5648bb1EncodeForumDomainEntity2(out *jwriter.Writer, in PrivateMessage) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"conversation\":"
+		out.RawString(prefix)
+		out.Int(int(in.Conversation))
+	}
+	{
+		const prefix string = ",\"author\":"
+		out.RawString(prefix)
+		out.String(string(in.Author))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.Raw((in.Created).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PrivateMessage) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA5648bb1EncodeForumDomainEntity2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PrivateMessage) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA5648bb1EncodeForumDomainEntity2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PrivateMessage) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA5648bb1DecodeForumDomainEntity2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PrivateMessage) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA5648bb1DecodeForumDomainEntity2(l, v)
+}
+func easyjsonA5648bb1DecodeForumDomainEntity3(in *jlexer.Lexer, out *IgnoredUsers) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(IgnoredUsers, 0, 4)
+			} else {
+				*out = IgnoredUsers{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v4 string
+			v4 = string(in.String())
+			*out = append(*out, v4)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA5648bb1EncodeForumDomainEntity3(out *jwriter.Writer, in IgnoredUsers) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v5, v6 := range in {
+			if v5 > 0 {
+				out.RawByte(',')
+			}
+			out.String(string(v6))
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v IgnoredUsers) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA5648bb1EncodeForumDomainEntity3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v IgnoredUsers) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA5648bb1EncodeForumDomainEntity3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *IgnoredUsers) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA5648bb1DecodeForumDomainEntity3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *IgnoredUsers) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA5648bb1DecodeForumDomainEntity3(l, v)
+}
+func easyjsonA5648bb1DecodeForumDomainEntity4(in *jlexer.Lexer, out *Conversations) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Conversations, 0, 1)
+			} else {
+				*out = Conversations{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v7 Conversation
+			(v7).UnmarshalEasyJSON(in)
+			*out = append(*out, v7)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA5648bb1EncodeForumDomainEntity4(out *jwriter.Writer, in Conversations) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v8, v9 := range in {
+			if v8 > 0 {
+				out.RawByte(',')
+			}
+			(v9).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Conversations) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA5648bb1EncodeForumDomainEntity4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Conversations) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA5648bb1EncodeForumDomainEntity4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Conversations) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA5648bb1DecodeForumDomainEntity4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Conversations) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA5648bb1DecodeForumDomainEntity4(l, v)
+}
+func easyjsonA5648bb1DecodeForumDomainEntity5(in *jlexer.Lexer, out *ConversationInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "with":
+			out.With = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA5648bb1EncodeForumDomainEntity5(out *jwriter.Writer, in ConversationInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"with\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.With))
+	}
+	if in.Message != "" {
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ConversationInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA5648bb1EncodeForumDomainEntity5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ConversationInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA5648bb1EncodeForumDomainEntity5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ConversationInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA5648bb1DecodeForumDomainEntity5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ConversationInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA5648bb1DecodeForumDomainEntity5(l, v)
+}
+func easyjsonA5648bb1DecodeForumDomainEntity6(in *jlexer.Lexer, out *Conversation) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "members":
+			if in.IsNull() {
+				in.Skip()
+				out.Members = nil
+			} else {
+				in.Delim('[')
+				if out.Members == nil {
+					if !in.IsDelim(']') {
+						out.Members = make([]string, 0, 4)
+					} else {
+						out.Members = []string{}
+					}
+				} else {
+					out.Members = (out.Members)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v10 string
+					v10 = string(in.String())
+					out.Members = append(out.Members, v10)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		case "lastMessage":
+			if in.IsNull() {
+				in.Skip()
+				out.LastMessage = nil
+			} else {
+				if out.LastMessage == nil {
+					out.LastMessage = new(PrivateMessage)
+				}
+				(*out.LastMessage).UnmarshalEasyJSON(in)
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA5648bb1EncodeForumDomainEntity6(out *jwriter.Writer, in Conversation) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"members\":"
+		out.RawString(prefix)
+		if in.Members == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v11, v12 := range in.Members {
+				if v11 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v12))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.Raw((in.Created).MarshalJSON())
+	}
+	if in.LastMessage != nil {
+		const prefix string = ",\"lastMessage\":"
+		out.RawString(prefix)
+		(*in.LastMessage).MarshalEasyJSON(out)
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Conversation) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA5648bb1EncodeForumDomainEntity6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Conversation) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA5648bb1EncodeForumDomainEntity6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Conversation) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA5648bb1DecodeForumDomainEntity6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Conversation) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA5648bb1DecodeForumDomainEntity6(l, v)
+}
+func easyjsonA5648bb1DecodeForumDomainEntity7(in *jlexer.Lexer, out *BlockedUsers) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(BlockedUsers, 0, 4)
+			} else {
+				*out = BlockedUsers{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v13 string
+			v13 = string(in.String())
+			*out = append(*out, v13)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonA5648bb1EncodeForumDomainEntity7(out *jwriter.Writer, in BlockedUsers) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v14, v15 := range in {
+			if v14 > 0 {
+				out.RawByte(',')
+			}
+			out.String(string(v15))
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v BlockedUsers) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonA5648bb1EncodeForumDomainEntity7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v BlockedUsers) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonA5648bb1EncodeForumDomainEntity7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *BlockedUsers) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonA5648bb1DecodeForumDomainEntity7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *BlockedUsers) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonA5648bb1DecodeForumDomainEntity7(l, v)
+}
@@ -6,7 +6,42 @@ const ForumNotExistError customError = "Forum not exists"
 const DataError customError = "Data error"
 const WrongParentError customError = "Wrong parent passed"
 const UserDoesntExistsError customError = "User does not exist"
-
+const SessionNotFoundError customError = "Session not found"
+const PermissionDeniedError customError = "Permission denied"
+const PasswordNotSetError customError = "Password is not set"
+const PasswordAlreadySetError customError = "Password is already set"
+const InvalidSetupTokenError customError = "Password setup token is invalid or expired"
+const WrongPasswordError customError = "Wrong password"
+const WeakPasswordError customError = "Password does not meet length requirements"
+const NotificationNotFoundError customError = "Notification not found"
+const UnknownNotificationTypeError customError = "Unknown notification type"
+const UnsupportedAvatarTypeError customError = "Avatar must be a jpeg, png or gif image"
+const AvatarTooLargeError customError = "Avatar is too large"
+const EmptySearchQueryError customError = "Search query is empty"
+const UnknownSearchTypeError customError = "Search type must be post or thread"
+const InvalidCursorError customError = "Invalid cursor"
+const PostNotFoundError customError = "Post not found"
+const ThreadNotFoundError customError = "Thread not found"
+const RevisionNotFoundError customError = "Revision not found"
+const ThreadLockedError customError = "Thread is locked"
+const ThreadArchivedError customError = "Thread is archived"
+const UserBannedError customError = "User is banned"
+const UserMutedError customError = "User is muted in this forum"
+const BanNotFoundError customError = "Ban not found"
+const UnknownReportReasonError customError = "Report reason must be spam, abuse, off-topic, illegal or other"
+const ReportAlreadyExistsError customError = "Target is already reported by this user"
+const ReportNotFoundError customError = "No open reports on target"
+const UnknownResolutionError customError = "Resolution action is unknown or does not apply to the target"
+const InvalidTimeRangeError customError = "since and until must be RFC 3339 timestamps"
+const UnknownReactionError customError = "Reaction is not in the configured set"
+const InvalidVoteError customError = "Voice must be -1, 0 or 1"
+const ConversationNotFoundError customError = "Conversation not found"
+const UserBlockedError customError = "User does not accept messages from you"
+const InvalidTagsError customError = "Invalid tags"
+const ForumIsCategoryError customError = "Forum is a category"
+const ForumHasSubForumsError customError = "Forum has sub-forums"
+const ThreadMovedError customError = "Thread was moved to another forum meanwhile"
+const ThreadIsRedirectError customError = "Thread is the redirect stub of a moved thread"
 
 func (err customError) Error() string { // customError implements error interface
 	return string(err)
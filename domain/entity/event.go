@@ -0,0 +1,27 @@
+package entity
+
+const PostCreatedEvent = "post.created"
+const PostEditedEvent = "post.edited"
+const ThreadCreatedEvent = "thread.created"
+const ThreadVotedEvent = "thread.voted"
+const ThreadUpdatedEvent = "thread.updated"
+
+const SubscribeAction = "subscribe"
+const UnsubscribeAction = "unsubscribe"
+
+// ForumEvent is sent to live channel subscribers, Post or Thread is filled depending on Type
+type ForumEvent struct {
+	Type     string  `json:"type"`
+	Forum    string  `json:"forum"`
+	ThreadID int     `json:"threadId"`
+	PostID   int     `json:"postId,omitempty"`
+	Post     *Post   `json:"post,omitempty"`
+	Thread   *Thread `json:"thread,omitempty"`
+}
+
+// LiveSubscription is a live channel client request to (un)subscribe from a forum or a thread
+type LiveSubscription struct {
+	Action string `json:"action"`
+	Forum  string `json:"forum,omitempty"`
+	Thread int    `json:"thread,omitempty"`
+}
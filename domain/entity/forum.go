@@ -1,15 +1,30 @@
 package entity
 
+// Forum counts its own Threads and Posts, the totals also include all of its sub-forums
 type Forum struct {
-	Slug    string `json:"slug"`
-	Title   string `json:"title"`
-	User    string `json:"user"`
-	Threads int    `json:"threads"`
-	Posts   int    `json:"posts"`
+	Slug         string   `json:"slug"`
+	Title        string   `json:"title"`
+	User         string   `json:"user"`
+	Threads      int      `json:"threads"`
+	Posts        int      `json:"posts"`
+	Moderators   []string `json:"moderators,omitempty"`
+	Parent       string   `json:"parent,omitempty"`
+	IsCategory   bool     `json:"isCategory,omitempty"`
+	TotalThreads int      `json:"totalThreads"`
+	TotalPosts   int      `json:"totalPosts"`
+	SubForums    Forums   `json:"subForums,omitempty"`
+}
+
+//easyjson:json
+type Forums []Forum
+
+type ForumModerators struct {
+	Forum      string   `json:"forum"`
+	Moderators []string `json:"moderators"`
 }
 
 type ForumInput struct {
-	Slug    string `json:"slug"`
-	Tittle  string `json:"title"`
-	User    string `json:"user"`
-}
\ No newline at end of file
+	Slug   string `json:"slug"`
+	Tittle string `json:"title"`
+	User   string `json:"user"`
+}
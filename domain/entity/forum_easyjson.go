@@ -17,7 +17,178 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjsonC8d74561DecodeForumDomainEntity(in *jlexer.Lexer, out *ForumInput) {
+func easyjsonC8d74561DecodeForumDomainEntity(in *jlexer.Lexer, out *Forums) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(Forums, 0, 0)
+			} else {
+				*out = Forums{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v1 Forum
+			(v1).UnmarshalEasyJSON(in)
+			*out = append(*out, v1)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC8d74561EncodeForumDomainEntity(out *jwriter.Writer, in Forums) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v2, v3 := range in {
+			if v2 > 0 {
+				out.RawByte(',')
+			}
+			(v3).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Forums) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC8d74561EncodeForumDomainEntity(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Forums) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC8d74561EncodeForumDomainEntity(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Forums) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC8d74561DecodeForumDomainEntity(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Forums) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC8d74561DecodeForumDomainEntity(l, v)
+}
+func easyjsonC8d74561DecodeForumDomainEntity1(in *jlexer.Lexer, out *ForumModerators) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "forum":
+			out.Forum = string(in.String())
+		case "moderators":
+			if in.IsNull() {
+				in.Skip()
+				out.Moderators = nil
+			} else {
+				in.Delim('[')
+				if out.Moderators == nil {
+					if !in.IsDelim(']') {
+						out.Moderators = make([]string, 0, 4)
+					} else {
+						out.Moderators = []string{}
+					}
+				} else {
+					out.Moderators = (out.Moderators)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.Moderators = append(out.Moderators, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonC8d74561EncodeForumDomainEntity1(out *jwriter.Writer, in ForumModerators) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"forum\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Forum))
+	}
+	{
+		const prefix string = ",\"moderators\":"
+		out.RawString(prefix)
+		if in.Moderators == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v5, v6 := range in.Moderators {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v6))
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ForumModerators) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonC8d74561EncodeForumDomainEntity1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ForumModerators) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonC8d74561EncodeForumDomainEntity1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ForumModerators) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonC8d74561DecodeForumDomainEntity1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ForumModerators) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonC8d74561DecodeForumDomainEntity1(l, v)
+}
+func easyjsonC8d74561DecodeForumDomainEntity2(in *jlexer.Lexer, out *ForumInput) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -52,7 +223,7 @@ func easyjsonC8d74561DecodeForumDomainEntity(in *jlexer.Lexer, out *ForumInput)
 		in.Consumed()
 	}
 }
-func easyjsonC8d74561EncodeForumDomainEntity(out *jwriter.Writer, in ForumInput) {
+func easyjsonC8d74561EncodeForumDomainEntity2(out *jwriter.Writer, in ForumInput) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -77,27 +248,27 @@ func easyjsonC8d74561EncodeForumDomainEntity(out *jwriter.Writer, in ForumInput)
 // MarshalJSON supports json.Marshaler interface
 func (v ForumInput) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC8d74561EncodeForumDomainEntity(&w, v)
+	easyjsonC8d74561EncodeForumDomainEntity2(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v ForumInput) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC8d74561EncodeForumDomainEntity(w, v)
+	easyjsonC8d74561EncodeForumDomainEntity2(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *ForumInput) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC8d74561DecodeForumDomainEntity(&r, v)
+	easyjsonC8d74561DecodeForumDomainEntity2(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *ForumInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC8d74561DecodeForumDomainEntity(l, v)
+	easyjsonC8d74561DecodeForumDomainEntity2(l, v)
 }
-func easyjsonC8d74561DecodeForumDomainEntity1(in *jlexer.Lexer, out *Forum) {
+func easyjsonC8d74561DecodeForumDomainEntity3(in *jlexer.Lexer, out *Forum) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -126,6 +297,39 @@ func easyjsonC8d74561DecodeForumDomainEntity1(in *jlexer.Lexer, out *Forum) {
 			out.Threads = int(in.Int())
 		case "posts":
 			out.Posts = int(in.Int())
+		case "moderators":
+			if in.IsNull() {
+				in.Skip()
+				out.Moderators = nil
+			} else {
+				in.Delim('[')
+				if out.Moderators == nil {
+					if !in.IsDelim(']') {
+						out.Moderators = make([]string, 0, 4)
+					} else {
+						out.Moderators = []string{}
+					}
+				} else {
+					out.Moderators = (out.Moderators)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 string
+					v7 = string(in.String())
+					out.Moderators = append(out.Moderators, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "parent":
+			out.Parent = string(in.String())
+		case "isCategory":
+			out.IsCategory = bool(in.Bool())
+		case "totalThreads":
+			out.TotalThreads = int(in.Int())
+		case "totalPosts":
+			out.TotalPosts = int(in.Int())
+		case "subForums":
+			(out.SubForums).UnmarshalEasyJSON(in)
 		default:
 			in.SkipRecursive()
 		}
@@ -136,7 +340,7 @@ func easyjsonC8d74561DecodeForumDomainEntity1(in *jlexer.Lexer, out *Forum) {
 		in.Consumed()
 	}
 }
-func easyjsonC8d74561EncodeForumDomainEntity1(out *jwriter.Writer, in Forum) {
+func easyjsonC8d74561EncodeForumDomainEntity3(out *jwriter.Writer, in Forum) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -165,29 +369,68 @@ func easyjsonC8d74561EncodeForumDomainEntity1(out *jwriter.Writer, in Forum) {
 		out.RawString(prefix)
 		out.Int(int(in.Posts))
 	}
+	if len(in.Moderators) != 0 {
+		const prefix string = ",\"moderators\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v8, v9 := range in.Moderators {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v9))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.Parent != "" {
+		const prefix string = ",\"parent\":"
+		out.RawString(prefix)
+		out.String(string(in.Parent))
+	}
+	if in.IsCategory {
+		const prefix string = ",\"isCategory\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsCategory))
+	}
+	{
+		const prefix string = ",\"totalThreads\":"
+		out.RawString(prefix)
+		out.Int(int(in.TotalThreads))
+	}
+	{
+		const prefix string = ",\"totalPosts\":"
+		out.RawString(prefix)
+		out.Int(int(in.TotalPosts))
+	}
+	if len(in.SubForums) != 0 {
+		const prefix string = ",\"subForums\":"
+		out.RawString(prefix)
+		(in.SubForums).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Forum) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjsonC8d74561EncodeForumDomainEntity1(&w, v)
+	easyjsonC8d74561EncodeForumDomainEntity3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Forum) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjsonC8d74561EncodeForumDomainEntity1(w, v)
+	easyjsonC8d74561EncodeForumDomainEntity3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Forum) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjsonC8d74561DecodeForumDomainEntity1(&r, v)
+	easyjsonC8d74561DecodeForumDomainEntity3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Forum) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjsonC8d74561DecodeForumDomainEntity1(l, v)
+	easyjsonC8d74561DecodeForumDomainEntity3(l, v)
 }
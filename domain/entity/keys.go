@@ -14,8 +14,26 @@ const SlugOrIDKey key = "slug_or_id"
 const RelatedKey key = "related"
 const SinceKey key = "since"
 const DescKey key = "desc"
+const UnreadKey key = "unread"
+const QueryKey key = "q"
+const ForumKey key = "forum"
+const AuthorKey key = "author"
+const TypeKey key = "type"
+const CursorKey key = "cursor"
+const FromKey key = "from"
+const ToKey key = "to"
+const ActiveKey key = "active"
+const ActorKey key = "actor"
+const ActionKey key = "action"
+const UntilKey key = "until"
+const ReactionKey key = "reaction"
+const TagKey key = "tag"
+const PrefixKey key = "prefix"
+
+const PasswordSetupTokenHeader = "X-Password-Setup-Token"
 
 const AvatarDefaultPath string = "assets/img/default-avatar.jpg"
+const AvatarPublicPath string = "avatars" // uploaded avatars are served from AVATAR_DIR under this path
 
 const AllNotificationsTypeKey string = "all-notifications"
 const OneNotificationTypeKey string = "notification"
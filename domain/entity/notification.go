@@ -0,0 +1,28 @@
+package entity
+
+import "github.com/go-openapi/strfmt"
+
+const NotificationReply = "reply"
+const NotificationMention = "mention"
+const NotificationVote = "vote"
+
+type Notification struct {
+	ID      int             `json:"id"`
+	Type    string          `json:"type"`
+	Actor   string          `json:"actor"`
+	Forum   string          `json:"forum"`
+	Thread  int             `json:"thread,omitempty"`
+	Post    int             `json:"post,omitempty"`
+	Created strfmt.DateTime `json:"created,omitempty"`
+	IsRead  bool            `json:"isRead"`
+}
+
+//easyjson:json
+type Notifications []Notification
+
+// NotificationReadInput marks either one notification (OneNotificationTypeKey)
+// or all of them (AllNotificationsTypeKey) as read
+type NotificationReadInput struct {
+	Type string `json:"type"`
+	ID   int    `json:"id,omitempty"`
+}
@@ -3,22 +3,67 @@ package entity
 import "github.com/go-openapi/strfmt"
 
 type Post struct {
-	ID       int             `json:"id"`
-	Author   string          `json:"author"`
-	Message  string          `json:"message"`
-	Parent   int             `json:"parent,omitempty"`
-	Forum    string          `json:"forum"`
-	Thread   int             `json:"thread"`
-	Created  strfmt.DateTime `json:"created,omitempty"`
-	IsEdited bool            `json:"isEdited"`
+	ID        int              `json:"id"`
+	Author    string           `json:"author"`
+	Message   string           `json:"message"`
+	Parent    int              `json:"parent,omitempty"`
+	Forum     string           `json:"forum"`
+	Thread    int              `json:"thread"`
+	Created   strfmt.DateTime  `json:"created,omitempty"`
+	IsEdited  bool             `json:"isEdited"`
+	IsDeleted bool             `json:"isDeleted,omitempty"`
+	IsIgnored bool             `json:"isIgnored,omitempty"`
+	Votes     int              `json:"votes"`
+	Reactions map[string]int32 `json:"reactions,omitempty"`
 }
 
+// DeletedPostMessage replaces the message of a soft-deleted post in every listing
+const DeletedPostMessage = "[deleted]"
+
+// IgnoredPostMessage replaces the message of a post whose author the viewer ignores
+const IgnoredPostMessage = "[ignored]"
+
 //easyjson:json
 type Posts []Post
 
+// DefaultPostReactions is the reaction set used when POST_REACTIONS is not configured
+var DefaultPostReactions = []string{"like", "love", "laugh", "wow", "sad", "angry"}
+
+type ReactionInput struct {
+	Reaction string `json:"reaction"`
+}
+
 type PostOutput struct {
-	Post   *Post   `json:"post"`
-	Author *User   `json:"author,omitempty"`
-	Thread *Thread `json:"thread,omitempty"`
-	Forum  *Forum  `json:"forum,omitempty"`
+	Post    *Post         `json:"post"`
+	Author  *User         `json:"author,omitempty"`
+	Thread  *Thread       `json:"thread,omitempty"`
+	Forum   *Forum        `json:"forum,omitempty"`
+	History PostRevisions `json:"history,omitempty"`
+}
+
+const DiffEqual = "equal"
+const DiffInsert = "insert"
+const DiffDelete = "delete"
+
+type PostRevision struct {
+	Revision int             `json:"revision"`
+	Editor   string          `json:"editor"`
+	Message  string          `json:"message"`
+	Created  strfmt.DateTime `json:"created"`
+}
+
+//easyjson:json
+type PostRevisions []PostRevision
+
+type DiffLine struct {
+	Op   string `json:"op"`
+	Text string `json:"text"`
+}
+
+type PostHistory struct {
+	Post      int           `json:"post"`
+	Revisions PostRevisions `json:"revisions"`
+	From      int           `json:"from,omitempty"`
+	To        int           `json:"to,omitempty"`
+	Diff      []DiffLine    `json:"diff,omitempty"`
 }
@@ -17,7 +17,73 @@ var (
 	_ easyjson.Marshaler
 )
 
-func easyjson5a72dc82DecodeForumDomainEntity(in *jlexer.Lexer, out *Posts) {
+func easyjson5a72dc82DecodeForumDomainEntity(in *jlexer.Lexer, out *ReactionInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "reaction":
+			out.Reaction = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5a72dc82EncodeForumDomainEntity(out *jwriter.Writer, in ReactionInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"reaction\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Reaction))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReactionInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson5a72dc82EncodeForumDomainEntity(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReactionInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5a72dc82EncodeForumDomainEntity(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReactionInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson5a72dc82DecodeForumDomainEntity(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReactionInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5a72dc82DecodeForumDomainEntity(l, v)
+}
+func easyjson5a72dc82DecodeForumDomainEntity1(in *jlexer.Lexer, out *Posts) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		in.Skip()
@@ -45,7 +111,7 @@ func easyjson5a72dc82DecodeForumDomainEntity(in *jlexer.Lexer, out *Posts) {
 		in.Consumed()
 	}
 }
-func easyjson5a72dc82EncodeForumDomainEntity(out *jwriter.Writer, in Posts) {
+func easyjson5a72dc82EncodeForumDomainEntity1(out *jwriter.Writer, in Posts) {
 	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
 		out.RawString("null")
 	} else {
@@ -63,27 +129,182 @@ func easyjson5a72dc82EncodeForumDomainEntity(out *jwriter.Writer, in Posts) {
 // MarshalJSON supports json.Marshaler interface
 func (v Posts) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5a72dc82EncodeForumDomainEntity(&w, v)
+	easyjson5a72dc82EncodeForumDomainEntity1(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Posts) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5a72dc82EncodeForumDomainEntity(w, v)
+	easyjson5a72dc82EncodeForumDomainEntity1(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Posts) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5a72dc82DecodeForumDomainEntity(&r, v)
+	easyjson5a72dc82DecodeForumDomainEntity1(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Posts) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5a72dc82DecodeForumDomainEntity(l, v)
+	easyjson5a72dc82DecodeForumDomainEntity1(l, v)
+}
+func easyjson5a72dc82DecodeForumDomainEntity2(in *jlexer.Lexer, out *PostRevisions) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(PostRevisions, 0, 1)
+			} else {
+				*out = PostRevisions{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v4 PostRevision
+			(v4).UnmarshalEasyJSON(in)
+			*out = append(*out, v4)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5a72dc82EncodeForumDomainEntity2(out *jwriter.Writer, in PostRevisions) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v5, v6 := range in {
+			if v5 > 0 {
+				out.RawByte(',')
+			}
+			(v6).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PostRevisions) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson5a72dc82EncodeForumDomainEntity2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PostRevisions) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5a72dc82EncodeForumDomainEntity2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PostRevisions) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson5a72dc82DecodeForumDomainEntity2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PostRevisions) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5a72dc82DecodeForumDomainEntity2(l, v)
+}
+func easyjson5a72dc82DecodeForumDomainEntity3(in *jlexer.Lexer, out *PostRevision) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "revision":
+			out.Revision = int(in.Int())
+		case "editor":
+			out.Editor = string(in.String())
+		case "message":
+			out.Message = string(in.String())
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5a72dc82EncodeForumDomainEntity3(out *jwriter.Writer, in PostRevision) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"revision\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Revision))
+	}
+	{
+		const prefix string = ",\"editor\":"
+		out.RawString(prefix)
+		out.String(string(in.Editor))
+	}
+	{
+		const prefix string = ",\"message\":"
+		out.RawString(prefix)
+		out.String(string(in.Message))
+	}
+	{
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.Raw((in.Created).MarshalJSON())
+	}
+	out.RawByte('}')
 }
-func easyjson5a72dc82DecodeForumDomainEntity1(in *jlexer.Lexer, out *PostOutput) {
+
+// MarshalJSON supports json.Marshaler interface
+func (v PostRevision) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson5a72dc82EncodeForumDomainEntity3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PostRevision) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5a72dc82EncodeForumDomainEntity3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PostRevision) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson5a72dc82DecodeForumDomainEntity3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PostRevision) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5a72dc82DecodeForumDomainEntity3(l, v)
+}
+func easyjson5a72dc82DecodeForumDomainEntity4(in *jlexer.Lexer, out *PostOutput) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -142,6 +363,8 @@ func easyjson5a72dc82DecodeForumDomainEntity1(in *jlexer.Lexer, out *PostOutput)
 				}
 				(*out.Forum).UnmarshalEasyJSON(in)
 			}
+		case "history":
+			(out.History).UnmarshalEasyJSON(in)
 		default:
 			in.SkipRecursive()
 		}
@@ -152,7 +375,7 @@ func easyjson5a72dc82DecodeForumDomainEntity1(in *jlexer.Lexer, out *PostOutput)
 		in.Consumed()
 	}
 }
-func easyjson5a72dc82EncodeForumDomainEntity1(out *jwriter.Writer, in PostOutput) {
+func easyjson5a72dc82EncodeForumDomainEntity4(out *jwriter.Writer, in PostOutput) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -180,33 +403,162 @@ func easyjson5a72dc82EncodeForumDomainEntity1(out *jwriter.Writer, in PostOutput
 		out.RawString(prefix)
 		(*in.Forum).MarshalEasyJSON(out)
 	}
+	if len(in.History) != 0 {
+		const prefix string = ",\"history\":"
+		out.RawString(prefix)
+		(in.History).MarshalEasyJSON(out)
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v PostOutput) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5a72dc82EncodeForumDomainEntity1(&w, v)
+	easyjson5a72dc82EncodeForumDomainEntity4(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v PostOutput) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5a72dc82EncodeForumDomainEntity1(w, v)
+	easyjson5a72dc82EncodeForumDomainEntity4(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *PostOutput) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5a72dc82DecodeForumDomainEntity1(&r, v)
+	easyjson5a72dc82DecodeForumDomainEntity4(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *PostOutput) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5a72dc82DecodeForumDomainEntity1(l, v)
+	easyjson5a72dc82DecodeForumDomainEntity4(l, v)
 }
-func easyjson5a72dc82DecodeForumDomainEntity2(in *jlexer.Lexer, out *Post) {
+func easyjson5a72dc82DecodeForumDomainEntity5(in *jlexer.Lexer, out *PostHistory) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "post":
+			out.Post = int(in.Int())
+		case "revisions":
+			(out.Revisions).UnmarshalEasyJSON(in)
+		case "from":
+			out.From = int(in.Int())
+		case "to":
+			out.To = int(in.Int())
+		case "diff":
+			if in.IsNull() {
+				in.Skip()
+				out.Diff = nil
+			} else {
+				in.Delim('[')
+				if out.Diff == nil {
+					if !in.IsDelim(']') {
+						out.Diff = make([]DiffLine, 0, 2)
+					} else {
+						out.Diff = []DiffLine{}
+					}
+				} else {
+					out.Diff = (out.Diff)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v7 DiffLine
+					(v7).UnmarshalEasyJSON(in)
+					out.Diff = append(out.Diff, v7)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5a72dc82EncodeForumDomainEntity5(out *jwriter.Writer, in PostHistory) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"post\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Post))
+	}
+	{
+		const prefix string = ",\"revisions\":"
+		out.RawString(prefix)
+		(in.Revisions).MarshalEasyJSON(out)
+	}
+	if in.From != 0 {
+		const prefix string = ",\"from\":"
+		out.RawString(prefix)
+		out.Int(int(in.From))
+	}
+	if in.To != 0 {
+		const prefix string = ",\"to\":"
+		out.RawString(prefix)
+		out.Int(int(in.To))
+	}
+	if len(in.Diff) != 0 {
+		const prefix string = ",\"diff\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v8, v9 := range in.Diff {
+				if v8 > 0 {
+					out.RawByte(',')
+				}
+				(v9).MarshalEasyJSON(out)
+			}
+			out.RawByte(']')
+		}
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PostHistory) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson5a72dc82EncodeForumDomainEntity5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PostHistory) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5a72dc82EncodeForumDomainEntity5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PostHistory) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson5a72dc82DecodeForumDomainEntity5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PostHistory) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5a72dc82DecodeForumDomainEntity5(l, v)
+}
+func easyjson5a72dc82DecodeForumDomainEntity6(in *jlexer.Lexer, out *Post) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -243,6 +595,32 @@ func easyjson5a72dc82DecodeForumDomainEntity2(in *jlexer.Lexer, out *Post) {
 			}
 		case "isEdited":
 			out.IsEdited = bool(in.Bool())
+		case "isDeleted":
+			out.IsDeleted = bool(in.Bool())
+		case "isIgnored":
+			out.IsIgnored = bool(in.Bool())
+		case "votes":
+			out.Votes = int(in.Int())
+		case "reactions":
+			if in.IsNull() {
+				in.Skip()
+			} else {
+				in.Delim('{')
+				if !in.IsDelim('}') {
+					out.Reactions = make(map[string]int32)
+				} else {
+					out.Reactions = nil
+				}
+				for !in.IsDelim('}') {
+					key := string(in.String())
+					in.WantColon()
+					var v10 int32
+					v10 = int32(in.Int32())
+					(out.Reactions)[key] = v10
+					in.WantComma()
+				}
+				in.Delim('}')
+			}
 		default:
 			in.SkipRecursive()
 		}
@@ -253,7 +631,7 @@ func easyjson5a72dc82DecodeForumDomainEntity2(in *jlexer.Lexer, out *Post) {
 		in.Consumed()
 	}
 }
-func easyjson5a72dc82EncodeForumDomainEntity2(out *jwriter.Writer, in Post) {
+func easyjson5a72dc82EncodeForumDomainEntity6(out *jwriter.Writer, in Post) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -297,29 +675,136 @@ func easyjson5a72dc82EncodeForumDomainEntity2(out *jwriter.Writer, in Post) {
 		out.RawString(prefix)
 		out.Bool(bool(in.IsEdited))
 	}
+	if in.IsDeleted {
+		const prefix string = ",\"isDeleted\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsDeleted))
+	}
+	if in.IsIgnored {
+		const prefix string = ",\"isIgnored\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.IsIgnored))
+	}
+	{
+		const prefix string = ",\"votes\":"
+		out.RawString(prefix)
+		out.Int(int(in.Votes))
+	}
+	if len(in.Reactions) != 0 {
+		const prefix string = ",\"reactions\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('{')
+			v11First := true
+			for v11Name, v11Value := range in.Reactions {
+				if v11First {
+					v11First = false
+				} else {
+					out.RawByte(',')
+				}
+				out.String(string(v11Name))
+				out.RawByte(':')
+				out.Int32(int32(v11Value))
+			}
+			out.RawByte('}')
+		}
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Post) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson5a72dc82EncodeForumDomainEntity2(&w, v)
+	easyjson5a72dc82EncodeForumDomainEntity6(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Post) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson5a72dc82EncodeForumDomainEntity2(w, v)
+	easyjson5a72dc82EncodeForumDomainEntity6(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Post) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson5a72dc82DecodeForumDomainEntity2(&r, v)
+	easyjson5a72dc82DecodeForumDomainEntity6(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Post) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson5a72dc82DecodeForumDomainEntity2(l, v)
+	easyjson5a72dc82DecodeForumDomainEntity6(l, v)
+}
+func easyjson5a72dc82DecodeForumDomainEntity7(in *jlexer.Lexer, out *DiffLine) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "op":
+			out.Op = string(in.String())
+		case "text":
+			out.Text = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson5a72dc82EncodeForumDomainEntity7(out *jwriter.Writer, in DiffLine) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"op\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Op))
+	}
+	{
+		const prefix string = ",\"text\":"
+		out.RawString(prefix)
+		out.String(string(in.Text))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v DiffLine) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson5a72dc82EncodeForumDomainEntity7(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v DiffLine) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson5a72dc82EncodeForumDomainEntity7(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *DiffLine) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson5a72dc82DecodeForumDomainEntity7(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *DiffLine) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson5a72dc82DecodeForumDomainEntity7(l, v)
 }
@@ -0,0 +1,71 @@
+package entity
+
+import "github.com/go-openapi/strfmt"
+
+const ReportTargetPost = "post"
+const ReportTargetThread = "thread"
+
+const ReportReasonSpam = "spam"
+const ReportReasonAbuse = "abuse"
+const ReportReasonOffTopic = "off-topic"
+const ReportReasonIllegal = "illegal"
+const ReportReasonOther = "other"
+
+const ResolutionDismiss = "dismiss"
+const ResolutionHidePost = "hide_post"
+const ResolutionLockThread = "lock_thread"
+const ResolutionBanAuthor = "ban_author"
+
+type Report struct {
+	ID       int             `json:"id"`
+	Type     string          `json:"type"`
+	Target   int             `json:"target"`
+	Forum    string          `json:"forum"`
+	Author   string          `json:"author"`
+	Reporter string          `json:"reporter"`
+	Reason   string          `json:"reason"`
+	Comment  string          `json:"comment,omitempty"`
+	Created  strfmt.DateTime `json:"created,omitempty"`
+}
+
+type ReportInput struct {
+	Reason  string `json:"reason"`
+	Comment string `json:"comment,omitempty"`
+}
+
+// ReportQueueItem aggregates the open reports on one post or thread
+type ReportQueueItem struct {
+	Type          string          `json:"type"`
+	Target        int             `json:"target"`
+	Author        string          `json:"author"`
+	Reports       int             `json:"reports"`
+	Reasons       []string        `json:"reasons"`
+	FirstReported strfmt.DateTime `json:"firstReported"`
+	LastReported  strfmt.DateTime `json:"lastReported"`
+}
+
+//easyjson:json
+type ReportQueue []ReportQueueItem
+
+// ResolutionAction is what ResolveReports applies to the target in the transaction that closes its reports:
+// the post is hidden, Thread is locked or Ban is created, Audit records that action
+//
+//easyjson:skip
+type ResolutionAction struct {
+	Thread int
+	Ban    *Ban
+	Audit  *AuditEntry
+}
+
+// ReportResolution closes every open report on the target; Reports is how many were closed
+type ReportResolution struct {
+	ID        int             `json:"id"`
+	Type      string          `json:"type"`
+	Target    int             `json:"target"`
+	Forum     string          `json:"forum"`
+	Action    string          `json:"action"`
+	Note      string          `json:"note,omitempty"`
+	Moderator string          `json:"moderator"`
+	Reports   int             `json:"reports"`
+	Created   strfmt.DateTime `json:"created,omitempty"`
+}
@@ -0,0 +1,550 @@
+// Code generated by easyjson for marshaling/unmarshaling. DO NOT EDIT.
+
+package entity
+
+import (
+	json "encoding/json"
+	easyjson "github.com/mailru/easyjson"
+	jlexer "github.com/mailru/easyjson/jlexer"
+	jwriter "github.com/mailru/easyjson/jwriter"
+)
+
+// suppress unused package warning
+var (
+	_ *json.RawMessage
+	_ *jlexer.Lexer
+	_ *jwriter.Writer
+	_ easyjson.Marshaler
+)
+
+func easyjsonBd361432DecodeForumDomainEntity(in *jlexer.Lexer, out *ReportResolution) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "type":
+			out.Type = string(in.String())
+		case "target":
+			out.Target = int(in.Int())
+		case "forum":
+			out.Forum = string(in.String())
+		case "action":
+			out.Action = string(in.String())
+		case "note":
+			out.Note = string(in.String())
+		case "moderator":
+			out.Moderator = string(in.String())
+		case "reports":
+			out.Reports = int(in.Int())
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonBd361432EncodeForumDomainEntity(out *jwriter.Writer, in ReportResolution) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"target\":"
+		out.RawString(prefix)
+		out.Int(int(in.Target))
+	}
+	{
+		const prefix string = ",\"forum\":"
+		out.RawString(prefix)
+		out.String(string(in.Forum))
+	}
+	{
+		const prefix string = ",\"action\":"
+		out.RawString(prefix)
+		out.String(string(in.Action))
+	}
+	if in.Note != "" {
+		const prefix string = ",\"note\":"
+		out.RawString(prefix)
+		out.String(string(in.Note))
+	}
+	{
+		const prefix string = ",\"moderator\":"
+		out.RawString(prefix)
+		out.String(string(in.Moderator))
+	}
+	{
+		const prefix string = ",\"reports\":"
+		out.RawString(prefix)
+		out.Int(int(in.Reports))
+	}
+	if true {
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.Raw((in.Created).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReportResolution) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonBd361432EncodeForumDomainEntity(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReportResolution) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonBd361432EncodeForumDomainEntity(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReportResolution) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonBd361432DecodeForumDomainEntity(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReportResolution) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonBd361432DecodeForumDomainEntity(l, v)
+}
+func easyjsonBd361432DecodeForumDomainEntity1(in *jlexer.Lexer, out *ReportQueueItem) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "type":
+			out.Type = string(in.String())
+		case "target":
+			out.Target = int(in.Int())
+		case "author":
+			out.Author = string(in.String())
+		case "reports":
+			out.Reports = int(in.Int())
+		case "reasons":
+			if in.IsNull() {
+				in.Skip()
+				out.Reasons = nil
+			} else {
+				in.Delim('[')
+				if out.Reasons == nil {
+					if !in.IsDelim(']') {
+						out.Reasons = make([]string, 0, 4)
+					} else {
+						out.Reasons = []string{}
+					}
+				} else {
+					out.Reasons = (out.Reasons)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v1 string
+					v1 = string(in.String())
+					out.Reasons = append(out.Reasons, v1)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "firstReported":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.FirstReported).UnmarshalJSON(data))
+			}
+		case "lastReported":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.LastReported).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonBd361432EncodeForumDomainEntity1(out *jwriter.Writer, in ReportQueueItem) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"target\":"
+		out.RawString(prefix)
+		out.Int(int(in.Target))
+	}
+	{
+		const prefix string = ",\"author\":"
+		out.RawString(prefix)
+		out.String(string(in.Author))
+	}
+	{
+		const prefix string = ",\"reports\":"
+		out.RawString(prefix)
+		out.Int(int(in.Reports))
+	}
+	{
+		const prefix string = ",\"reasons\":"
+		out.RawString(prefix)
+		if in.Reasons == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+			out.RawString("null")
+		} else {
+			out.RawByte('[')
+			for v2, v3 := range in.Reasons {
+				if v2 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v3))
+			}
+			out.RawByte(']')
+		}
+	}
+	{
+		const prefix string = ",\"firstReported\":"
+		out.RawString(prefix)
+		out.Raw((in.FirstReported).MarshalJSON())
+	}
+	{
+		const prefix string = ",\"lastReported\":"
+		out.RawString(prefix)
+		out.Raw((in.LastReported).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReportQueueItem) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonBd361432EncodeForumDomainEntity1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReportQueueItem) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonBd361432EncodeForumDomainEntity1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReportQueueItem) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonBd361432DecodeForumDomainEntity1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReportQueueItem) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonBd361432DecodeForumDomainEntity1(l, v)
+}
+func easyjsonBd361432DecodeForumDomainEntity2(in *jlexer.Lexer, out *ReportQueue) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(ReportQueue, 0, 0)
+			} else {
+				*out = ReportQueue{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v4 ReportQueueItem
+			(v4).UnmarshalEasyJSON(in)
+			*out = append(*out, v4)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonBd361432EncodeForumDomainEntity2(out *jwriter.Writer, in ReportQueue) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v5, v6 := range in {
+			if v5 > 0 {
+				out.RawByte(',')
+			}
+			(v6).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReportQueue) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonBd361432EncodeForumDomainEntity2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReportQueue) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonBd361432EncodeForumDomainEntity2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReportQueue) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonBd361432DecodeForumDomainEntity2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReportQueue) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonBd361432DecodeForumDomainEntity2(l, v)
+}
+func easyjsonBd361432DecodeForumDomainEntity3(in *jlexer.Lexer, out *ReportInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "reason":
+			out.Reason = string(in.String())
+		case "comment":
+			out.Comment = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonBd361432EncodeForumDomainEntity3(out *jwriter.Writer, in ReportInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Reason))
+	}
+	if in.Comment != "" {
+		const prefix string = ",\"comment\":"
+		out.RawString(prefix)
+		out.String(string(in.Comment))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReportInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonBd361432EncodeForumDomainEntity3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReportInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonBd361432EncodeForumDomainEntity3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReportInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonBd361432DecodeForumDomainEntity3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReportInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonBd361432DecodeForumDomainEntity3(l, v)
+}
+func easyjsonBd361432DecodeForumDomainEntity4(in *jlexer.Lexer, out *Report) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "id":
+			out.ID = int(in.Int())
+		case "type":
+			out.Type = string(in.String())
+		case "target":
+			out.Target = int(in.Int())
+		case "forum":
+			out.Forum = string(in.String())
+		case "author":
+			out.Author = string(in.String())
+		case "reporter":
+			out.Reporter = string(in.String())
+		case "reason":
+			out.Reason = string(in.String())
+		case "comment":
+			out.Comment = string(in.String())
+		case "created":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Created).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjsonBd361432EncodeForumDomainEntity4(out *jwriter.Writer, in Report) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"id\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.ID))
+	}
+	{
+		const prefix string = ",\"type\":"
+		out.RawString(prefix)
+		out.String(string(in.Type))
+	}
+	{
+		const prefix string = ",\"target\":"
+		out.RawString(prefix)
+		out.Int(int(in.Target))
+	}
+	{
+		const prefix string = ",\"forum\":"
+		out.RawString(prefix)
+		out.String(string(in.Forum))
+	}
+	{
+		const prefix string = ",\"author\":"
+		out.RawString(prefix)
+		out.String(string(in.Author))
+	}
+	{
+		const prefix string = ",\"reporter\":"
+		out.RawString(prefix)
+		out.String(string(in.Reporter))
+	}
+	{
+		const prefix string = ",\"reason\":"
+		out.RawString(prefix)
+		out.String(string(in.Reason))
+	}
+	if in.Comment != "" {
+		const prefix string = ",\"comment\":"
+		out.RawString(prefix)
+		out.String(string(in.Comment))
+	}
+	if true {
+		const prefix string = ",\"created\":"
+		out.RawString(prefix)
+		out.Raw((in.Created).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v Report) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjsonBd361432EncodeForumDomainEntity4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v Report) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjsonBd361432EncodeForumDomainEntity4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *Report) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjsonBd361432DecodeForumDomainEntity4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *Report) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjsonBd361432DecodeForumDomainEntity4(l, v)
+}
@@ -0,0 +1,8 @@
+package entity
+
+const RoleAdmin = "admin"
+const RoleModerator = "moderator"
+const RoleMember = "member"
+
+// RoleBanned is what GetRole reports for a user under an active global ban
+const RoleBanned = "banned"
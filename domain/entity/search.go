@@ -0,0 +1,35 @@
+package entity
+
+import "github.com/go-openapi/strfmt"
+
+const SearchTypePost = "post"
+const SearchTypeThread = "thread"
+
+type SearchResult struct {
+	Type    string          `json:"type"`
+	ID      int             `json:"id"`
+	Thread  int             `json:"thread"`
+	Forum   string          `json:"forum"`
+	Author  string          `json:"author"`
+	Title   string          `json:"title,omitempty"`
+	Snippet string          `json:"snippet"`
+	Rank    float32         `json:"rank"`
+	Created strfmt.DateTime `json:"created,omitempty"`
+}
+
+type SearchResults struct {
+	Results []SearchResult `json:"results"`
+	Cursor  string         `json:"cursor,omitempty"`
+}
+
+// SearchQuery continues after the (AfterRank, AfterID) result when AfterID is set
+//
+//easyjson:skip
+type SearchQuery struct {
+	Text      string
+	Forum     string
+	Author    string
+	Limit     int32
+	AfterRank float32
+	AfterID   int
+}
@@ -0,0 +1,14 @@
+package entity
+
+import "time"
+
+type Session struct {
+	ID       string    `json:"-"`
+	Nickname string    `json:"nickname"`
+	Expires  time.Time `json:"expires"`
+}
+
+type Credentials struct {
+	Nickname string `json:"nickname"`
+	Password string `json:"password"`
+}
@@ -0,0 +1,37 @@
+package entity
+
+import "time"
+
+const FeedItemThread = "thread"
+const FeedItemPost = "post"
+
+// FeedItem is a new thread in a subscribed forum or a new post in a subscribed thread
+type FeedItem struct {
+	Type   string  `json:"type"`
+	Thread *Thread `json:"thread,omitempty"`
+	Post   *Post   `json:"post,omitempty"`
+}
+
+type Feed struct {
+	Items  []FeedItem `json:"items"`
+	Cursor string     `json:"cursor,omitempty"`
+}
+
+// FeedQuery continues after the (AfterCreated, AfterType, AfterID) item when AfterID is set,
+// posts of one batch share created so the type and id break the tie
+//
+//easyjson:skip
+type FeedQuery struct {
+	Limit        int32
+	Since        string
+	Desc         bool
+	AfterCreated time.Time
+	AfterType    string
+	AfterID      int
+}
+
+// Subscriptions lists what the user follows; every thread carries its unread post count
+type Subscriptions struct {
+	Threads Threads  `json:"threads"`
+	Forums  []string `json:"forums"`
+}
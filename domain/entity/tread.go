@@ -3,14 +3,53 @@ package entity
 import "github.com/go-openapi/strfmt"
 
 type Thread struct {
-	ID      int             `json:"id"`
-	Forum   string          `json:"forum"`
-	Title   string          `json:"title"`
-	Author  string          `json:"author"`
-	Message string          `json:"message"`
-	Slug    *string         `json:"slug,omitempty"`
-	Created strfmt.DateTime `json:"created,omitempty"`
-	Votes   int             `json:"votes"`
+	ID       int             `json:"id"`
+	Forum    string          `json:"forum"`
+	Title    string          `json:"title"`
+	Author   string          `json:"author"`
+	Message  string          `json:"message"`
+	Slug     *string         `json:"slug,omitempty"`
+	Created  strfmt.DateTime `json:"created,omitempty"`
+	Votes    int             `json:"votes"`
+	Locked   bool            `json:"locked,omitempty"`
+	Pinned   bool            `json:"pinned,omitempty"`
+	Archived bool            `json:"archived,omitempty"`
+	Unread   int             `json:"unread,omitempty"`
+	Tags     []string        `json:"tags,omitempty"`
+	MovedTo  int             `json:"movedTo,omitempty"`
+}
+
+// ThreadMoveInput moves a thread to Forum, LeaveRedirect keeps a locked stub pointing at it in the old forum
+type ThreadMoveInput struct {
+	Forum         string `json:"forum"`
+	LeaveRedirect bool   `json:"leaveRedirect,omitempty"`
+}
+
+// MovedThreadMessage is the message of a redirect stub, it takes the new forum and the thread id
+const MovedThreadMessage = "This thread has moved to forum %v, see thread %v"
+
+const MaxThreadTags = 10
+const MaxTagLength = 32
+
+// TagCount is the number of threads carrying Tag, within a forum or across all of them
+type TagCount struct {
+	Tag     string `json:"tag"`
+	Threads int    `json:"threads"`
+}
+
+//easyjson:json
+type TagCounts []TagCount
+
+// ThreadStateInput changes only the flags that are present
+type ThreadStateInput struct {
+	Locked   *bool `json:"locked,omitempty"`
+	Pinned   *bool `json:"pinned,omitempty"`
+	Archived *bool `json:"archived,omitempty"`
+}
+
+// ReadMarkerInput moves the read marker of a thread to Post, or to the newest post when Post is 0
+type ReadMarkerInput struct {
+	Post int `json:"post"`
 }
 
 //easyjson:json
@@ -83,7 +83,195 @@ func (v *Threads) UnmarshalJSON(data []byte) error {
 func (v *Threads) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson8d42b382DecodeForumDomainEntity(l, v)
 }
-func easyjson8d42b382DecodeForumDomainEntity1(in *jlexer.Lexer, out *Thread) {
+func easyjson8d42b382DecodeForumDomainEntity1(in *jlexer.Lexer, out *ThreadStateInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "locked":
+			if in.IsNull() {
+				in.Skip()
+				out.Locked = nil
+			} else {
+				if out.Locked == nil {
+					out.Locked = new(bool)
+				}
+				*out.Locked = bool(in.Bool())
+			}
+		case "pinned":
+			if in.IsNull() {
+				in.Skip()
+				out.Pinned = nil
+			} else {
+				if out.Pinned == nil {
+					out.Pinned = new(bool)
+				}
+				*out.Pinned = bool(in.Bool())
+			}
+		case "archived":
+			if in.IsNull() {
+				in.Skip()
+				out.Archived = nil
+			} else {
+				if out.Archived == nil {
+					out.Archived = new(bool)
+				}
+				*out.Archived = bool(in.Bool())
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson8d42b382EncodeForumDomainEntity1(out *jwriter.Writer, in ThreadStateInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	if in.Locked != nil {
+		const prefix string = ",\"locked\":"
+		first = false
+		out.RawString(prefix[1:])
+		out.Bool(bool(*in.Locked))
+	}
+	if in.Pinned != nil {
+		const prefix string = ",\"pinned\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(*in.Pinned))
+	}
+	if in.Archived != nil {
+		const prefix string = ",\"archived\":"
+		if first {
+			first = false
+			out.RawString(prefix[1:])
+		} else {
+			out.RawString(prefix)
+		}
+		out.Bool(bool(*in.Archived))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ThreadStateInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson8d42b382EncodeForumDomainEntity1(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ThreadStateInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson8d42b382EncodeForumDomainEntity1(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ThreadStateInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson8d42b382DecodeForumDomainEntity1(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ThreadStateInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson8d42b382DecodeForumDomainEntity1(l, v)
+}
+func easyjson8d42b382DecodeForumDomainEntity2(in *jlexer.Lexer, out *ThreadMoveInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "forum":
+			out.Forum = string(in.String())
+		case "leaveRedirect":
+			out.LeaveRedirect = bool(in.Bool())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson8d42b382EncodeForumDomainEntity2(out *jwriter.Writer, in ThreadMoveInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"forum\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Forum))
+	}
+	if in.LeaveRedirect {
+		const prefix string = ",\"leaveRedirect\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.LeaveRedirect))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ThreadMoveInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson8d42b382EncodeForumDomainEntity2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ThreadMoveInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson8d42b382EncodeForumDomainEntity2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ThreadMoveInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson8d42b382DecodeForumDomainEntity2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ThreadMoveInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson8d42b382DecodeForumDomainEntity2(l, v)
+}
+func easyjson8d42b382DecodeForumDomainEntity3(in *jlexer.Lexer, out *Thread) {
 	isTopLevel := in.IsStart()
 	if in.IsNull() {
 		if isTopLevel {
@@ -128,6 +316,39 @@ func easyjson8d42b382DecodeForumDomainEntity1(in *jlexer.Lexer, out *Thread) {
 			}
 		case "votes":
 			out.Votes = int(in.Int())
+		case "locked":
+			out.Locked = bool(in.Bool())
+		case "pinned":
+			out.Pinned = bool(in.Bool())
+		case "archived":
+			out.Archived = bool(in.Bool())
+		case "unread":
+			out.Unread = int(in.Int())
+		case "tags":
+			if in.IsNull() {
+				in.Skip()
+				out.Tags = nil
+			} else {
+				in.Delim('[')
+				if out.Tags == nil {
+					if !in.IsDelim(']') {
+						out.Tags = make([]string, 0, 4)
+					} else {
+						out.Tags = []string{}
+					}
+				} else {
+					out.Tags = (out.Tags)[:0]
+				}
+				for !in.IsDelim(']') {
+					var v4 string
+					v4 = string(in.String())
+					out.Tags = append(out.Tags, v4)
+					in.WantComma()
+				}
+				in.Delim(']')
+			}
+		case "movedTo":
+			out.MovedTo = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -138,7 +359,7 @@ func easyjson8d42b382DecodeForumDomainEntity1(in *jlexer.Lexer, out *Thread) {
 		in.Consumed()
 	}
 }
-func easyjson8d42b382EncodeForumDomainEntity1(out *jwriter.Writer, in Thread) {
+func easyjson8d42b382EncodeForumDomainEntity3(out *jwriter.Writer, in Thread) {
 	out.RawByte('{')
 	first := true
 	_ = first
@@ -182,29 +403,273 @@ func easyjson8d42b382EncodeForumDomainEntity1(out *jwriter.Writer, in Thread) {
 		out.RawString(prefix)
 		out.Int(int(in.Votes))
 	}
+	if in.Locked {
+		const prefix string = ",\"locked\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Locked))
+	}
+	if in.Pinned {
+		const prefix string = ",\"pinned\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Pinned))
+	}
+	if in.Archived {
+		const prefix string = ",\"archived\":"
+		out.RawString(prefix)
+		out.Bool(bool(in.Archived))
+	}
+	if in.Unread != 0 {
+		const prefix string = ",\"unread\":"
+		out.RawString(prefix)
+		out.Int(int(in.Unread))
+	}
+	if len(in.Tags) != 0 {
+		const prefix string = ",\"tags\":"
+		out.RawString(prefix)
+		{
+			out.RawByte('[')
+			for v5, v6 := range in.Tags {
+				if v5 > 0 {
+					out.RawByte(',')
+				}
+				out.String(string(v6))
+			}
+			out.RawByte(']')
+		}
+	}
+	if in.MovedTo != 0 {
+		const prefix string = ",\"movedTo\":"
+		out.RawString(prefix)
+		out.Int(int(in.MovedTo))
+	}
 	out.RawByte('}')
 }
 
 // MarshalJSON supports json.Marshaler interface
 func (v Thread) MarshalJSON() ([]byte, error) {
 	w := jwriter.Writer{}
-	easyjson8d42b382EncodeForumDomainEntity1(&w, v)
+	easyjson8d42b382EncodeForumDomainEntity3(&w, v)
 	return w.Buffer.BuildBytes(), w.Error
 }
 
 // MarshalEasyJSON supports easyjson.Marshaler interface
 func (v Thread) MarshalEasyJSON(w *jwriter.Writer) {
-	easyjson8d42b382EncodeForumDomainEntity1(w, v)
+	easyjson8d42b382EncodeForumDomainEntity3(w, v)
 }
 
 // UnmarshalJSON supports json.Unmarshaler interface
 func (v *Thread) UnmarshalJSON(data []byte) error {
 	r := jlexer.Lexer{Data: data}
-	easyjson8d42b382DecodeForumDomainEntity1(&r, v)
+	easyjson8d42b382DecodeForumDomainEntity3(&r, v)
 	return r.Error()
 }
 
 // UnmarshalEasyJSON supports easyjson.Unmarshaler interface
 func (v *Thread) UnmarshalEasyJSON(l *jlexer.Lexer) {
-	easyjson8d42b382DecodeForumDomainEntity1(l, v)
+	easyjson8d42b382DecodeForumDomainEntity3(l, v)
+}
+func easyjson8d42b382DecodeForumDomainEntity4(in *jlexer.Lexer, out *TagCounts) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		in.Skip()
+		*out = nil
+	} else {
+		in.Delim('[')
+		if *out == nil {
+			if !in.IsDelim(']') {
+				*out = make(TagCounts, 0, 2)
+			} else {
+				*out = TagCounts{}
+			}
+		} else {
+			*out = (*out)[:0]
+		}
+		for !in.IsDelim(']') {
+			var v7 TagCount
+			(v7).UnmarshalEasyJSON(in)
+			*out = append(*out, v7)
+			in.WantComma()
+		}
+		in.Delim(']')
+	}
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson8d42b382EncodeForumDomainEntity4(out *jwriter.Writer, in TagCounts) {
+	if in == nil && (out.Flags&jwriter.NilSliceAsEmpty) == 0 {
+		out.RawString("null")
+	} else {
+		out.RawByte('[')
+		for v8, v9 := range in {
+			if v8 > 0 {
+				out.RawByte(',')
+			}
+			(v9).MarshalEasyJSON(out)
+		}
+		out.RawByte(']')
+	}
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TagCounts) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson8d42b382EncodeForumDomainEntity4(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TagCounts) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson8d42b382EncodeForumDomainEntity4(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TagCounts) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson8d42b382DecodeForumDomainEntity4(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TagCounts) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson8d42b382DecodeForumDomainEntity4(l, v)
+}
+func easyjson8d42b382DecodeForumDomainEntity5(in *jlexer.Lexer, out *TagCount) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "tag":
+			out.Tag = string(in.String())
+		case "threads":
+			out.Threads = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson8d42b382EncodeForumDomainEntity5(out *jwriter.Writer, in TagCount) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"tag\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Tag))
+	}
+	{
+		const prefix string = ",\"threads\":"
+		out.RawString(prefix)
+		out.Int(int(in.Threads))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v TagCount) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson8d42b382EncodeForumDomainEntity5(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v TagCount) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson8d42b382EncodeForumDomainEntity5(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *TagCount) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson8d42b382DecodeForumDomainEntity5(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *TagCount) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson8d42b382DecodeForumDomainEntity5(l, v)
+}
+func easyjson8d42b382DecodeForumDomainEntity6(in *jlexer.Lexer, out *ReadMarkerInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "post":
+			out.Post = int(in.Int())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson8d42b382EncodeForumDomainEntity6(out *jwriter.Writer, in ReadMarkerInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"post\":"
+		out.RawString(prefix[1:])
+		out.Int(int(in.Post))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v ReadMarkerInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson8d42b382EncodeForumDomainEntity6(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v ReadMarkerInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson8d42b382EncodeForumDomainEntity6(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *ReadMarkerInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson8d42b382DecodeForumDomainEntity6(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *ReadMarkerInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson8d42b382DecodeForumDomainEntity6(l, v)
 }
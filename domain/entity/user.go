@@ -1,5 +1,7 @@
 package entity
 
+import "time"
+
 //
 //const usernameRegexp = "^[a-zA-Z][a-zA-Z0-9_]{1,41}$"
 //const firstNameRegexp = "^[a-zA-Z ]{0,42}$"
@@ -11,7 +13,23 @@ type User struct {
 	Fullname string `json:"fullname,omitempty"`
 	Email    string `json:"email,omitempty"`
 	About    string `json:"about,omitempty"`
+	Avatar   string `json:"avatar,omitempty"`
+	Karma    int    `json:"karma"`
 }
 
 //easyjson:json
 type Users []User
+
+// PasswordInput sets the first password with Token, or replaces the current one with OldPassword
+type PasswordInput struct {
+	Password    string `json:"password"`
+	OldPassword string `json:"oldPassword,omitempty"`
+	Token       string `json:"token,omitempty"`
+}
+
+// PasswordSetupToken is handed out once, when the account is created or by an admin for older accounts
+type PasswordSetupToken struct {
+	Nickname string    `json:"nickname"`
+	Token    string    `json:"token"`
+	Expires  time.Time `json:"expires"`
+}
@@ -110,6 +110,10 @@ func easyjson9e1087fdDecodeForumDomainEntity1(in *jlexer.Lexer, out *User) {
 			out.Email = string(in.String())
 		case "about":
 			out.About = string(in.String())
+		case "avatar":
+			out.Avatar = string(in.String())
+		case "karma":
+			out.Karma = int(in.Int())
 		default:
 			in.SkipRecursive()
 		}
@@ -149,6 +153,16 @@ func easyjson9e1087fdEncodeForumDomainEntity1(out *jwriter.Writer, in User) {
 		out.RawString(prefix)
 		out.String(string(in.About))
 	}
+	if in.Avatar != "" {
+		const prefix string = ",\"avatar\":"
+		out.RawString(prefix)
+		out.String(string(in.Avatar))
+	}
+	{
+		const prefix string = ",\"karma\":"
+		out.RawString(prefix)
+		out.Int(int(in.Karma))
+	}
 	out.RawByte('}')
 }
 
@@ -175,3 +189,165 @@ func (v *User) UnmarshalJSON(data []byte) error {
 func (v *User) UnmarshalEasyJSON(l *jlexer.Lexer) {
 	easyjson9e1087fdDecodeForumDomainEntity1(l, v)
 }
+func easyjson9e1087fdDecodeForumDomainEntity2(in *jlexer.Lexer, out *PasswordSetupToken) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "nickname":
+			out.Nickname = string(in.String())
+		case "token":
+			out.Token = string(in.String())
+		case "expires":
+			if data := in.Raw(); in.Ok() {
+				in.AddError((out.Expires).UnmarshalJSON(data))
+			}
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeForumDomainEntity2(out *jwriter.Writer, in PasswordSetupToken) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"nickname\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Nickname))
+	}
+	{
+		const prefix string = ",\"token\":"
+		out.RawString(prefix)
+		out.String(string(in.Token))
+	}
+	{
+		const prefix string = ",\"expires\":"
+		out.RawString(prefix)
+		out.Raw((in.Expires).MarshalJSON())
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PasswordSetupToken) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeForumDomainEntity2(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PasswordSetupToken) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeForumDomainEntity2(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PasswordSetupToken) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeForumDomainEntity2(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PasswordSetupToken) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeForumDomainEntity2(l, v)
+}
+func easyjson9e1087fdDecodeForumDomainEntity3(in *jlexer.Lexer, out *PasswordInput) {
+	isTopLevel := in.IsStart()
+	if in.IsNull() {
+		if isTopLevel {
+			in.Consumed()
+		}
+		in.Skip()
+		return
+	}
+	in.Delim('{')
+	for !in.IsDelim('}') {
+		key := in.UnsafeFieldName(false)
+		in.WantColon()
+		if in.IsNull() {
+			in.Skip()
+			in.WantComma()
+			continue
+		}
+		switch key {
+		case "password":
+			out.Password = string(in.String())
+		case "oldPassword":
+			out.OldPassword = string(in.String())
+		case "token":
+			out.Token = string(in.String())
+		default:
+			in.SkipRecursive()
+		}
+		in.WantComma()
+	}
+	in.Delim('}')
+	if isTopLevel {
+		in.Consumed()
+	}
+}
+func easyjson9e1087fdEncodeForumDomainEntity3(out *jwriter.Writer, in PasswordInput) {
+	out.RawByte('{')
+	first := true
+	_ = first
+	{
+		const prefix string = ",\"password\":"
+		out.RawString(prefix[1:])
+		out.String(string(in.Password))
+	}
+	if in.OldPassword != "" {
+		const prefix string = ",\"oldPassword\":"
+		out.RawString(prefix)
+		out.String(string(in.OldPassword))
+	}
+	if in.Token != "" {
+		const prefix string = ",\"token\":"
+		out.RawString(prefix)
+		out.String(string(in.Token))
+	}
+	out.RawByte('}')
+}
+
+// MarshalJSON supports json.Marshaler interface
+func (v PasswordInput) MarshalJSON() ([]byte, error) {
+	w := jwriter.Writer{}
+	easyjson9e1087fdEncodeForumDomainEntity3(&w, v)
+	return w.Buffer.BuildBytes(), w.Error
+}
+
+// MarshalEasyJSON supports easyjson.Marshaler interface
+func (v PasswordInput) MarshalEasyJSON(w *jwriter.Writer) {
+	easyjson9e1087fdEncodeForumDomainEntity3(w, v)
+}
+
+// UnmarshalJSON supports json.Unmarshaler interface
+func (v *PasswordInput) UnmarshalJSON(data []byte) error {
+	r := jlexer.Lexer{Data: data}
+	easyjson9e1087fdDecodeForumDomainEntity3(&r, v)
+	return r.Error()
+}
+
+// UnmarshalEasyJSON supports easyjson.Unmarshaler interface
+func (v *PasswordInput) UnmarshalEasyJSON(l *jlexer.Lexer) {
+	easyjson9e1087fdDecodeForumDomainEntity3(l, v)
+}
@@ -6,4 +6,16 @@ type Vote struct {
 	Voice    int    `json:"voice"`
 	ID       int    `json:"id"`
 	Slug     string `json:"slug"`
-}
\ No newline at end of file
+}
+
+// ThreadVote is one voice on a thread; Slug and Title are only set when listing the votes of a user
+type ThreadVote struct {
+	Nickname string `json:"nickname"`
+	Thread   int    `json:"thread"`
+	Slug     string `json:"slug,omitempty"`
+	Title    string `json:"title,omitempty"`
+	Voice    int    `json:"voice"`
+}
+
+//easyjson:json
+type ThreadVotes []ThreadVote
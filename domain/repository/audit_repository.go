@@ -0,0 +1,7 @@
+package repository
+
+import "forum/domain/entity"
+
+type AuditRepository interface {
+	GetAuditEntries(query *entity.AuditQuery) ([]entity.AuditEntry, error)
+}
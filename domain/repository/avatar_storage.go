@@ -0,0 +1,6 @@
+package repository
+
+type AvatarStorage interface {
+	SaveAvatar(name string, data []byte) (string, error)
+	DeleteAvatar(path string) error
+}
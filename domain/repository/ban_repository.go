@@ -0,0 +1,10 @@
+package repository
+
+import "forum/domain/entity"
+
+type BanRepository interface {
+	CreateBan(ban *entity.Ban, audit *entity.AuditEntry) error
+	GetBans(activeOnly bool) ([]entity.Ban, error)
+	LiftBan(banID int, liftedBy string, audit *entity.AuditEntry) (*entity.Ban, error)
+	GetActiveBan(nickname string, forum string) (*entity.Ban, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import "forum/domain/entity"
+
+type ConversationRepository interface {
+	CreateConversation(nickname string, with string) (int, error)
+	GetConversation(conversationID int) (*entity.Conversation, error)
+	GetConversations(nickname string, limit int32, since int) ([]entity.Conversation, error)
+	AddMessage(message *entity.PrivateMessage) error
+	GetMessages(conversationID int, limit int32, since int, desc bool) ([]entity.PrivateMessage, error)
+	BlockUser(nickname string, blocked string) error
+	UnblockUser(nickname string, blocked string) error
+	GetBlockedUsers(nickname string) ([]string, error)
+	IsBlocked(nickname string, with string) (bool, error)
+}
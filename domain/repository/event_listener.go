@@ -0,0 +1,14 @@
+package repository
+
+import "forum/domain/entity"
+
+type EventSubscription interface {
+	Events() <-chan *entity.ForumEvent
+	Follow(forum string, threadID int)
+	Unfollow(forum string, threadID int)
+	Close()
+}
+
+type EventListener interface {
+	Subscribe(buffer int) EventSubscription
+}
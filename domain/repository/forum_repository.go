@@ -6,6 +6,8 @@ type ForumRepository interface {
 	CreateForum(forumInput *entity.Forum) error
 	GetForumDetails(slug string) (*entity.Forum, error)
 	GetForumUsers(slug string, limit int32, since string, order string, compare string) ([]entity.User, error)
+	GetForumUsersByKarma(slug string, limit int32, since string, order string, compare string) ([]entity.User, error)
 	CheckForum(slug string) (string, error)
+	GetForums() ([]entity.Forum, error)
+	DeleteForum(slug string, audit *entity.AuditEntry) error
 }
-
@@ -0,0 +1,7 @@
+package repository
+
+type IgnoreRepository interface {
+	IgnoreUser(nickname string, ignored string) error
+	UnignoreUser(nickname string, ignored string) error
+	GetIgnoredUsers(nickname string) ([]string, error)
+}
@@ -0,0 +1,10 @@
+package repository
+
+import "forum/domain/entity"
+
+type NotificationRepository interface {
+	GetNotifications(nickname string, limit int32, since int, desc bool, unread bool) ([]entity.Notification, error)
+	GetNotification(nickname string, ID int) (*entity.Notification, error)
+	MarkNotificationRead(nickname string, ID int) error
+	MarkAllNotificationsRead(nickname string) error
+}
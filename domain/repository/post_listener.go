@@ -0,0 +1,5 @@
+package repository
+
+type PostListener interface {
+	Subscribe(threadID int) (<-chan struct{}, func())
+}
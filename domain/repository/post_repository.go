@@ -4,6 +4,11 @@ import "forum/domain/entity"
 
 type PostRepository interface {
 	GetPostDetails(postID int) (*entity.Post, error)
-	ChangePostMessage(post *entity.Post) (*entity.Post, error)
+	ChangePostMessage(post *entity.Post, audit *entity.AuditEntry) (*entity.Post, error)
+	DeletePost(postID int, audit *entity.AuditEntry) error
+	RestorePost(postID int, audit *entity.AuditEntry) (*entity.Post, error)
+	GetPostRevisions(postID int) ([]entity.PostRevision, error)
+	AddPostReaction(postID int, nickname string, reaction string) error
+	RemovePostReaction(postID int, nickname string, reaction string) error
+	VoteForPost(vote *entity.Vote) (*entity.Post, error)
 }
-
@@ -0,0 +1,9 @@
+package repository
+
+import "forum/domain/entity"
+
+type ReportRepository interface {
+	CreateReport(report *entity.Report) error
+	GetReportQueue(forum string, limit int32) ([]entity.ReportQueueItem, error)
+	ResolveReports(resolution *entity.ReportResolution, action *entity.ResolutionAction, audit *entity.AuditEntry) error
+}
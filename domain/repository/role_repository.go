@@ -0,0 +1,10 @@
+package repository
+
+import "forum/domain/entity"
+
+type RoleRepository interface {
+	GetRole(nickname string, forum string) (string, error)
+	GetForumModerators(forum string) ([]string, error)
+	AddForumModerator(forum string, nickname string, audit *entity.AuditEntry) ([]string, error)
+	RemoveForumModerator(forum string, nickname string, audit *entity.AuditEntry) ([]string, error)
+}
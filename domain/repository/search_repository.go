@@ -0,0 +1,8 @@
+package repository
+
+import "forum/domain/entity"
+
+type SearchRepository interface {
+	SearchPosts(query *entity.SearchQuery) ([]entity.SearchResult, error)
+	SearchThreads(query *entity.SearchQuery) ([]entity.SearchResult, error)
+}
@@ -3,6 +3,6 @@ package repository
 import "forum/domain/entity"
 
 type ServiceRepository interface {
-	ClearAllDate() error
+	ClearAllDate(audit *entity.AuditEntry) error
 	GetDBStatus() (*entity.Status, error)
 }
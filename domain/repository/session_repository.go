@@ -0,0 +1,9 @@
+package repository
+
+import "forum/domain/entity"
+
+type SessionRepository interface {
+	CreateSession(session *entity.Session) error
+	GetUserBySession(sessionID string) (*entity.User, error)
+	DeleteSession(sessionID string) error
+}
@@ -0,0 +1,14 @@
+package repository
+
+import "forum/domain/entity"
+
+type SubscriptionRepository interface {
+	SubscribeThread(nickname string, threadID int) error
+	UnsubscribeThread(nickname string, threadID int) error
+	SubscribeForum(nickname string, forum string) error
+	UnsubscribeForum(nickname string, forum string) error
+	GetFeed(nickname string, query *entity.FeedQuery) ([]entity.FeedItem, error)
+	MarkFeedThreadsSeen(nickname string, threadIDs []int) error
+	GetSubscribedThreads(nickname string) ([]entity.Thread, error)
+	GetSubscribedForums(nickname string) ([]string, error)
+}
@@ -10,10 +10,23 @@ type ThreadRepository interface {
 	GetThreadPostsParentTree(slug string, limit int32, since string, order string) ([]entity.Post, error)
 	CheckThreadBySlug(slug string) (int, error)
 	GetThreadForumAndID(slugOrID string) (*entity.Thread, error)
-	GetThreadsByForumSlug(slug string, limit int32, since string, desc bool) ([]entity.Thread, error)
+	GetThreadsByForumSlug(slug string, limit int32, since string, desc bool, tag string) ([]entity.Thread, error)
+	GetThreadsByTag(tag string, limit int32, since string, desc bool) ([]entity.Thread, error)
+	GetForumTags(forum string) ([]entity.TagCount, error)
+	SearchTags(prefix string, limit int32) ([]entity.TagCount, error)
 	CheckThreadByID(ID int) error
 	VoteForThread(vote *entity.Vote) (*entity.Thread, error)
+	RetractThreadVote(nickname string, threadID int) error
+	GetThreadVotes(threadID int, limit int32, since string, order string, compare string) ([]entity.ThreadVote, error)
+	GetUserThreadVotes(nickname string, limit int32, since int, order string, compare string) ([]entity.ThreadVote, error)
 	GetThreadBySlug(slug string) (*entity.Thread, error)
 	GetThreadByID(ID int) (*entity.Thread, error)
-	UpdateThread(thread *entity.Thread) error
+	UpdateThread(thread *entity.Thread, audit *entity.AuditEntry) error
+	GetLastPostID(threadID int) (int, error)
+	DeleteThread(thread *entity.Thread, audit *entity.AuditEntry) error
+	MoveThread(thread *entity.Thread, forum string, stub *entity.Thread, audit *entity.AuditEntry) error
+	UpdateThreadState(threadID int, state *entity.ThreadStateInput, audit *entity.AuditEntry) error
+	MarkThreadRead(nickname string, threadID int, firstID int, lastID int) error
+	SetReadMarker(nickname string, threadID int, postID int) error
+	GetUnreadCounts(nickname string, threadIDs []int) (map[int]int, error)
 }
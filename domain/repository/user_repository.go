@@ -1,6 +1,9 @@
 package repository
 
-import "forum/domain/entity"
+import (
+	"forum/domain/entity"
+	"time"
+)
 
 type UserRepository interface {
 	CreateUser(user *entity.User) error
@@ -9,4 +12,9 @@ type UserRepository interface {
 	UpdateUser(newUser *entity.User) (*entity.User, error)
 	GetUserNicknameWithEmail(email string) (string, error)
 	GetUsersWithNicknameAndEmail(nickname, email string) ([]entity.User, error)
-}
\ No newline at end of file
+	GetPasswordHash(nickname string) ([]byte, error)
+	SetPasswordHash(nickname string, hash []byte) error
+	SetPasswordSetupToken(nickname string, tokenHash string, expires time.Time) error
+	SetFirstPasswordHash(nickname string, tokenHash string, hash []byte) error
+	UpdateAvatar(nickname string, avatar string) error
+}
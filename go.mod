@@ -5,14 +5,18 @@ go 1.16
 require (
 	github.com/asaskevich/govalidator v0.0.0-20210307081110-f21760c49a8d // indirect
 	github.com/fasthttp/router v1.4.0
+	github.com/fasthttp/websocket v1.5.0
 	github.com/go-openapi/errors v0.20.0 // indirect
 	github.com/go-openapi/strfmt v0.20.1
 	github.com/jackc/pgx/v4 v4.11.0
 	github.com/joho/godotenv v1.3.0
-	github.com/mailru/easyjson v0.7.7 // indirect
+	github.com/klauspost/compress v1.14.4 // indirect
+	github.com/mailru/easyjson v0.7.7
 	github.com/mitchellh/mapstructure v1.4.1 // indirect
-	github.com/valyala/fasthttp v1.27.0
+	github.com/valyala/fasthttp v1.33.0
 	go.mongodb.org/mongo-driver v1.5.3 // indirect
 	go.uber.org/zap v1.17.0
+	golang.org/x/crypto v0.0.0-20220112180741-5e0467b6c7ce
+	golang.org/x/image v0.5.0
 	gopkg.in/yaml.v2 v2.4.0 // indirect
 )
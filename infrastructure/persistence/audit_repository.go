@@ -0,0 +1,112 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"forum/domain/entity"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/mailru/easyjson"
+)
+
+type AuditRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewAuditRepository(db *pgxpool.Pool) *AuditRepo {
+	return &AuditRepo{db: db}
+}
+
+const AddAuditEntryQuery = `INSERT INTO audit_log (actor, action, target_type, target, forum, before, after)
+		VALUES ($1, $2, $3, $4, NULLIF($5, '')::CITEXT, $6, $7)
+		RETURNING id, created`
+
+// addAuditEntry writes the entry in the transaction of the audited action, target is what the action left behind
+// and goes to After; a nil entry records nothing, a nil target means the target is gone
+func addAuditEntry(tx pgx.Tx, entry *entity.AuditEntry, target easyjson.Marshaler) error {
+	if entry == nil {
+		return nil
+	}
+
+	var err error
+	if target != nil {
+		entry.After, err = easyjson.Marshal(target)
+		if err != nil {
+			return err
+		}
+	}
+
+	var before, after interface{}
+	if len(entry.Before) != 0 {
+		before = string(entry.Before)
+	}
+	if len(entry.After) != 0 {
+		after = string(entry.After)
+	}
+
+	return tx.QueryRow(context.Background(), AddAuditEntryQuery,
+		entry.Actor, entry.Action, entry.TargetType, entry.Target, entry.Forum, before, after,
+	).Scan(&entry.ID, &entry.Created)
+}
+
+func (a *AuditRepo) GetAuditEntries(query *entity.AuditQuery) ([]entity.AuditEntry, error) {
+	var filters string
+	var args []interface{}
+	if query.Actor != "" {
+		args = append(args, query.Actor)
+		filters += fmt.Sprintf(" AND actor = $%d", len(args))
+	}
+	if query.Forum != "" {
+		args = append(args, query.Forum)
+		filters += fmt.Sprintf(" AND forum = $%d", len(args))
+	}
+	if query.Action != "" {
+		args = append(args, query.Action)
+		filters += fmt.Sprintf(" AND action = $%d", len(args))
+	}
+	if !query.Since.IsZero() {
+		args = append(args, query.Since)
+		filters += fmt.Sprintf(" AND created >= $%d", len(args))
+	}
+	if !query.Until.IsZero() {
+		args = append(args, query.Until)
+		filters += fmt.Sprintf(" AND created < $%d", len(args))
+	}
+	if query.AfterID != 0 {
+		args = append(args, query.AfterID)
+		filters += fmt.Sprintf(" AND id < $%d", len(args))
+	}
+
+	sqlQuery := fmt.Sprintf(`SELECT id, actor, action, target_type, target, COALESCE(forum, ''),
+			COALESCE(before::TEXT, ''), COALESCE(after::TEXT, ''), created
+		FROM audit_log
+		WHERE TRUE%s
+		ORDER BY id DESC
+		LIMIT %d`, filters, query.Limit)
+
+	rows, err := a.db.Query(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	entries := make([]entity.AuditEntry, 0, query.Limit)
+	for rows.Next() {
+		entry := entity.AuditEntry{}
+		var before, after string
+		err = rows.Scan(&entry.ID, &entry.Actor, &entry.Action, &entry.TargetType, &entry.Target, &entry.Forum,
+			&before, &after, &entry.Created)
+		if err != nil {
+			return nil, err
+		}
+		if before != "" {
+			entry.Before = []byte(before)
+		}
+		if after != "" {
+			entry.After = []byte(after)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
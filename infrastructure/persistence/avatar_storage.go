@@ -0,0 +1,46 @@
+package persistence
+
+import (
+	"forum/domain/entity"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+type LocalAvatarStorage struct {
+	root string
+}
+
+// NewLocalAvatarStorage stores avatars as plain files under root directory,
+// saved files are addressed by their public path under entity.AvatarPublicPath
+func NewLocalAvatarStorage(root string) (*LocalAvatarStorage, error) {
+	err := os.MkdirAll(root, 0755)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalAvatarStorage{root: filepath.Clean(root)}, nil
+}
+
+func (s *LocalAvatarStorage) SaveAvatar(name string, data []byte) (string, error) {
+	name = filepath.Base(name)
+	err := ioutil.WriteFile(filepath.Join(s.root, name), data, 0644)
+	if err != nil {
+		return "", err
+	}
+	return path.Join(entity.AvatarPublicPath, name), nil
+}
+
+func (s *LocalAvatarStorage) DeleteAvatar(publicPath string) error {
+	publicPath = path.Clean(publicPath)
+	if !strings.HasPrefix(publicPath, entity.AvatarPublicPath+"/") {
+		return nil // not ours, e.g. the default avatar
+	}
+
+	err := os.Remove(filepath.Join(s.root, path.Base(publicPath)))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
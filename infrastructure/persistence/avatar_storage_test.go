@@ -0,0 +1,42 @@
+package persistence
+
+import (
+	"forum/domain/entity"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLocalAvatarStorage(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "avatars")
+	storage, err := NewLocalAvatarStorage(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicPath, err := storage.SaveAvatar("../alice_128.jpg", []byte("jpeg"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if publicPath != entity.AvatarPublicPath+"/alice_128.jpg" {
+		t.Errorf("got public path %v", publicPath)
+	}
+	_, err = os.Stat(filepath.Join(root, "alice_128.jpg"))
+	if err != nil {
+		t.Fatalf("avatar is not stored under the root: %v", err)
+	}
+
+	err = storage.DeleteAvatar(entity.AvatarDefaultPath)
+	if err != nil {
+		t.Errorf("deleting the default avatar: %v", err)
+	}
+
+	err = storage.DeleteAvatar(publicPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = os.Stat(filepath.Join(root, "alice_128.jpg"))
+	if !os.IsNotExist(err) {
+		t.Errorf("avatar is still stored: %v", err)
+	}
+}
@@ -0,0 +1,166 @@
+package persistence
+
+import (
+	"context"
+	"forum/domain/entity"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"strconv"
+)
+
+const banActiveCondition = `lifted IS NULL AND (expires IS NULL OR expires > now())`
+const banColumns = `id, nickname, COALESCE(forum, ''), reason, issued_by, created, expires, lifted, COALESCE(lifted_by, '')`
+
+type BanRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewBanRepository(db *pgxpool.Pool) *BanRepo {
+	return &BanRepo{db: db}
+}
+
+func scanBan(row pgx.Row, ban *entity.Ban) error {
+	return row.Scan(
+		&ban.ID,
+		&ban.Nickname,
+		&ban.Forum,
+		&ban.Reason,
+		&ban.IssuedBy,
+		&ban.Created,
+		&ban.Expires,
+		&ban.Lifted,
+		&ban.LiftedBy)
+}
+
+const CreateBanQuery = `INSERT INTO bans (nickname, forum, reason, issued_by, expires)
+		VALUES ($1, NULLIF($2, '')::CITEXT, $3, $4, $5)
+		RETURNING id, created`
+
+// CreateBan targets the audit entry at the new ban, in the forum as the ban spells it
+func (b *BanRepo) CreateBan(ban *entity.Ban, audit *entity.AuditEntry) error {
+	err := b.db.QueryRow(context.Background(), CheckUserExistQuery, ban.Nickname).Scan(&ban.Nickname)
+	if err == pgx.ErrNoRows {
+		return entity.UserDoesntExistsError
+	}
+	if err != nil {
+		return err
+	}
+
+	if ban.Forum != "" {
+		err = b.db.QueryRow(context.Background(), CheckForumQuery, ban.Forum).Scan(&ban.Forum)
+		if err == pgx.ErrNoRows {
+			return entity.ForumNotExistError
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	tx, err := b.db.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	err = tx.QueryRow(context.Background(), CreateBanQuery,
+		ban.Nickname, ban.Forum, ban.Reason, ban.IssuedBy, ban.Expires,
+	).Scan(&ban.ID, &ban.Created)
+	if err != nil {
+		return err
+	}
+
+	if audit != nil {
+		audit.Target = strconv.Itoa(ban.ID)
+		audit.Forum = ban.Forum
+	}
+	err = addAuditEntry(tx, audit, ban)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(context.Background())
+}
+
+const GetBansQuery = `SELECT ` + banColumns + ` FROM bans`
+const GetActiveBansQuery = GetBansQuery + ` WHERE ` + banActiveCondition
+
+func (b *BanRepo) GetBans(activeOnly bool) ([]entity.Ban, error) {
+	query := GetBansQuery
+	if activeOnly {
+		query = GetActiveBansQuery
+	}
+	query += ` ORDER BY id DESC`
+
+	rows, err := b.db.Query(context.Background(), query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bans := make([]entity.Ban, 0)
+	for rows.Next() {
+		ban := entity.Ban{}
+		err = scanBan(rows, &ban)
+		if err != nil {
+			return nil, err
+		}
+		bans = append(bans, ban)
+	}
+
+	return bans, nil
+}
+
+const LiftBanQuery = `UPDATE bans SET lifted = now(), lifted_by = $2
+		WHERE id = $1 AND lifted IS NULL
+		RETURNING ` + banColumns
+
+// LiftBan puts the audit entry into the forum of the ban
+func (b *BanRepo) LiftBan(banID int, liftedBy string, audit *entity.AuditEntry) (*entity.Ban, error) {
+	tx, err := b.db.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+
+	ban := &entity.Ban{}
+	err = scanBan(tx.QueryRow(context.Background(), LiftBanQuery, banID, liftedBy), ban)
+	if err == pgx.ErrNoRows {
+		return nil, entity.BanNotFoundError
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if audit != nil {
+		audit.Forum = ban.Forum
+	}
+	err = addAuditEntry(tx, audit, ban)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return ban, nil
+}
+
+// a global ban is reported before a mute in the forum
+const GetActiveBanQuery = `SELECT ` + banColumns + ` FROM bans
+		WHERE nickname = $1 AND (forum IS NULL OR forum = $2) AND ` + banActiveCondition + `
+		ORDER BY forum NULLS FIRST
+		LIMIT 1`
+
+func (b *BanRepo) GetActiveBan(nickname string, forum string) (*entity.Ban, error) {
+	ban := &entity.Ban{}
+	err := scanBan(b.db.QueryRow(context.Background(), GetActiveBanQuery, nickname, forum), ban)
+	if err == pgx.ErrNoRows {
+		return nil, entity.BanNotFoundError
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return ban, nil
+}
@@ -0,0 +1,207 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"forum/domain/entity"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type ConversationRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewConversationRepository(db *pgxpool.Pool) *ConversationRepo {
+	return &ConversationRepo{db: db}
+}
+
+// CreateConversationQuery touches the row of an existing pair so that RETURNING yields its id too,
+// xmax is 0 only for a freshly inserted row
+const CreateConversationQuery = `INSERT INTO conversations (member_low, member_high)
+	VALUES (LEAST($1::CITEXT, $2::CITEXT), GREATEST($1::CITEXT, $2::CITEXT))
+	ON CONFLICT (member_low, member_high) DO UPDATE SET member_low = EXCLUDED.member_low
+	RETURNING id, xmax = 0`
+const AddConversationMemberQuery = `INSERT INTO conversation_members (conversation_id, nickname) VALUES ($1, $2)`
+
+// CreateConversation returns the conversation of the two users, creating it when they have never talked;
+// concurrent calls for the same pair end up with the same conversation
+func (c *ConversationRepo) CreateConversation(nickname string, with string) (int, error) {
+	tx, err := c.db.Begin(context.Background())
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback(context.Background())
+
+	var id int
+	var created bool
+	err = tx.QueryRow(context.Background(), CreateConversationQuery, nickname, with).Scan(&id, &created)
+	if err != nil {
+		return 0, err
+	}
+	if !created {
+		return id, nil
+	}
+
+	for _, member := range []string{nickname, with} {
+		_, err = tx.Exec(context.Background(), AddConversationMemberQuery, id, member)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return id, tx.Commit(context.Background())
+}
+
+const conversationColumns = `c.id, c.created,
+		ARRAY(SELECT nickname::TEXT FROM conversation_members WHERE conversation_id = c.id ORDER BY nickname),
+		COALESCE(m.id, 0), COALESCE(m.author, ''), COALESCE(m.msg, ''), COALESCE(m.created, c.created)`
+
+func scanConversation(row pgx.Row) (*entity.Conversation, error) {
+	conversation := &entity.Conversation{}
+	message := &entity.PrivateMessage{}
+	err := row.Scan(&conversation.ID, &conversation.Created, &conversation.Members,
+		&message.ID, &message.Author, &message.Message, &message.Created)
+	if err != nil {
+		return nil, err
+	}
+
+	if message.ID != 0 {
+		message.Conversation = conversation.ID
+		conversation.LastMessage = message
+	}
+	return conversation, nil
+}
+
+const GetConversationQuery = `SELECT ` + conversationColumns + `
+	FROM conversations AS c
+	LEFT JOIN messages AS m ON m.id = c.last_message
+	WHERE c.id = $1`
+
+func (c *ConversationRepo) GetConversation(conversationID int) (*entity.Conversation, error) {
+	return scanConversation(c.db.QueryRow(context.Background(), GetConversationQuery, conversationID))
+}
+
+const GetConversationsQuery = `SELECT ` + conversationColumns + `
+	FROM conversation_members AS cm
+	JOIN conversations AS c ON c.id = cm.conversation_id
+	LEFT JOIN messages AS m ON m.id = c.last_message
+	WHERE cm.nickname = $1 AND ($2 = 0 OR c.last_message < $2)
+	ORDER BY c.last_message DESC, c.id DESC
+	LIMIT $3`
+
+// GetConversations lists the most recently active conversations first, since is the last message id of the previous page
+func (c *ConversationRepo) GetConversations(nickname string, limit int32, since int) ([]entity.Conversation, error) {
+	rows, err := c.db.Query(context.Background(), GetConversationsQuery, nickname, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	conversations := make([]entity.Conversation, 0, limit)
+	for rows.Next() {
+		conversation, err := scanConversation(rows)
+		if err != nil {
+			return nil, err
+		}
+		conversations = append(conversations, *conversation)
+	}
+
+	return conversations, nil
+}
+
+const AddMessageQuery = `INSERT INTO messages (conversation_id, author, msg) VALUES ($1, $2, $3)
+	RETURNING id, created`
+
+func (c *ConversationRepo) AddMessage(message *entity.PrivateMessage) error {
+	return c.db.QueryRow(context.Background(), AddMessageQuery, message.Conversation, message.Author, message.Message).
+		Scan(&message.ID, &message.Created)
+}
+
+func (c *ConversationRepo) GetMessages(conversationID int, limit int32, since int, desc bool) ([]entity.PrivateMessage, error) {
+	query := `SELECT id, author, msg, created FROM messages WHERE conversation_id = $1`
+	order := "ASC"
+	compare := ">"
+	if desc {
+		order = "DESC"
+		compare = "<"
+	}
+
+	if since != 0 {
+		query += fmt.Sprintf(" AND id %v $2", compare)
+	}
+
+	query += fmt.Sprintf(" ORDER BY id %v LIMIT %v", order, limit)
+	var rows pgx.Rows
+	var err error
+	if since != 0 {
+		rows, err = c.db.Query(context.Background(), query, conversationID, since)
+	} else {
+		rows, err = c.db.Query(context.Background(), query, conversationID)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	messages := make([]entity.PrivateMessage, 0, limit)
+	for rows.Next() {
+		message := entity.PrivateMessage{Conversation: conversationID}
+		err = rows.Scan(&message.ID, &message.Author, &message.Message, &message.Created)
+		if err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
+
+const BlockUserQuery = `INSERT INTO user_blocks (nickname, blocked) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+
+func (c *ConversationRepo) BlockUser(nickname string, blocked string) error {
+	_, err := c.db.Exec(context.Background(), BlockUserQuery, nickname, blocked)
+	return err
+}
+
+const UnblockUserQuery = `DELETE FROM user_blocks WHERE nickname = $1 AND blocked = $2`
+
+func (c *ConversationRepo) UnblockUser(nickname string, blocked string) error {
+	_, err := c.db.Exec(context.Background(), UnblockUserQuery, nickname, blocked)
+	return err
+}
+
+const GetBlockedUsersQuery = `SELECT blocked FROM user_blocks WHERE nickname = $1 ORDER BY blocked`
+
+func (c *ConversationRepo) GetBlockedUsers(nickname string) ([]string, error) {
+	rows, err := c.db.Query(context.Background(), GetBlockedUsersQuery, nickname)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	blocked := make([]string, 0)
+	for rows.Next() {
+		var user string
+		err = rows.Scan(&user)
+		if err != nil {
+			return nil, err
+		}
+		blocked = append(blocked, user)
+	}
+
+	return blocked, nil
+}
+
+const IsBlockedQuery = `SELECT EXISTS(SELECT 1 FROM user_blocks
+		WHERE (nickname = $1 AND blocked = $2) OR (nickname = $2 AND blocked = $1))
+	OR EXISTS(SELECT 1 FROM user_ignores WHERE nickname = $2 AND ignored = $1)`
+
+// IsBlocked reports whether nickname may not message with: a block stops messages both ways,
+// an ignore only stops those from the ignored user to the one ignoring them
+func (c *ConversationRepo) IsBlocked(nickname string, with string) (bool, error) {
+	var blocked bool
+	err := c.db.QueryRow(context.Background(), IsBlockedQuery, nickname, with).Scan(&blocked)
+	return blocked, err
+}
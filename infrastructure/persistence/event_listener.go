@@ -0,0 +1,194 @@
+package persistence
+
+import (
+	"forum/domain/entity"
+	"forum/domain/repository"
+	"strings"
+	"sync"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+	json "github.com/mailru/easyjson"
+)
+
+const forumEventsChannel = "forum_events"
+const pendingEventsBuffer = 1024
+
+// EventListener receives forum events from the forum_events NOTIFY channel, which is fed
+// by posts and threads triggers, loads the changed post or thread once
+// and fans it out to the subscriptions following its forum or thread.
+// Loading happens on a goroutine of its own so the shared listener connection is never held up by queries
+type EventListener struct {
+	posts         *PostRepo
+	threads       *ThreadRepo
+	pending       chan *entity.ForumEvent
+	mu            sync.Mutex
+	subscriptions map[*eventSubscription]struct{}
+}
+
+func NewEventListener(db *pgxpool.Pool, listener *Listener) *EventListener {
+	l := &EventListener{
+		posts:         NewPostRepository(db),
+		threads:       NewThreadRepository(db),
+		pending:       make(chan *entity.ForumEvent, pendingEventsBuffer),
+		subscriptions: make(map[*eventSubscription]struct{}),
+	}
+	listener.Handle(forumEventsChannel, l.handle, nil)
+	go l.load()
+	return l
+}
+
+// Subscribe creates subscription with room for buffer undelivered events,
+// subscription that falls further behind is closed
+func (l *EventListener) Subscribe(buffer int) repository.EventSubscription {
+	subscription := &eventSubscription{
+		listener: l,
+		events:   make(chan *entity.ForumEvent, buffer),
+		forums:   make(map[string]struct{}),
+		threads:  make(map[int]struct{}),
+	}
+
+	l.mu.Lock()
+	l.subscriptions[subscription] = struct{}{}
+	l.mu.Unlock()
+
+	return subscription
+}
+
+// handle runs on the listener goroutine, it only queues events that somebody follows.
+// When the loader is that far behind, the followers of the event are closed as if they could not keep up
+func (l *EventListener) handle(payload string) {
+	event := &entity.ForumEvent{}
+	err := json.Unmarshal([]byte(payload), event)
+	if err != nil {
+		return
+	}
+
+	if !l.hasFollowers(event) {
+		return
+	}
+
+	select {
+	case l.pending <- event:
+	default:
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		for subscription := range l.subscriptions {
+			if subscription.follows(event) {
+				l.remove(subscription)
+			}
+		}
+	}
+}
+
+// load fetches the post or thread of every queued event in order and delivers it
+func (l *EventListener) load() {
+	for event := range l.pending {
+		var err error
+		switch event.Type {
+		case entity.PostCreatedEvent, entity.PostEditedEvent:
+			event.Post, err = l.posts.GetPostDetails(event.PostID)
+		default:
+			event.Thread, err = l.threads.GetThreadByID(event.ThreadID)
+		}
+		if err != nil {
+			continue
+		}
+
+		l.deliver(event)
+	}
+}
+
+func (l *EventListener) deliver(event *entity.ForumEvent) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for subscription := range l.subscriptions {
+		if !subscription.follows(event) {
+			continue
+		}
+
+		select {
+		case subscription.events <- event:
+		default:
+			l.remove(subscription)
+		}
+	}
+}
+
+func (l *EventListener) hasFollowers(event *entity.ForumEvent) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for subscription := range l.subscriptions {
+		if subscription.follows(event) {
+			return true
+		}
+	}
+	return false
+}
+
+// remove must be called with l.mu held
+func (l *EventListener) remove(subscription *eventSubscription) {
+	_, ok := l.subscriptions[subscription]
+	if !ok {
+		return
+	}
+	delete(l.subscriptions, subscription)
+	close(subscription.events)
+}
+
+type eventSubscription struct {
+	listener *EventListener
+	events   chan *entity.ForumEvent
+	mu       sync.Mutex
+	forums   map[string]struct{}
+	threads  map[int]struct{}
+}
+
+// Events is closed once the subscription is closed or could not keep up
+func (s *eventSubscription) Events() <-chan *entity.ForumEvent {
+	return s.events
+}
+
+func (s *eventSubscription) Follow(forum string, threadID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if forum != "" {
+		s.forums[strings.ToLower(forum)] = struct{}{}
+	}
+	if threadID != 0 {
+		s.threads[threadID] = struct{}{}
+	}
+}
+
+func (s *eventSubscription) Unfollow(forum string, threadID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if forum != "" {
+		delete(s.forums, strings.ToLower(forum))
+	}
+	if threadID != 0 {
+		delete(s.threads, threadID)
+	}
+}
+
+func (s *eventSubscription) Close() {
+	s.listener.mu.Lock()
+	defer s.listener.mu.Unlock()
+	s.listener.remove(s)
+}
+
+func (s *eventSubscription) follows(event *entity.ForumEvent) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, ok := s.forums[strings.ToLower(event.Forum)]
+	if ok {
+		return true
+	}
+	_, ok = s.threads[event.ThreadID]
+	return ok
+}
@@ -2,8 +2,10 @@ package persistence
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"forum/domain/entity"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -15,13 +17,18 @@ func NewForumRepository(db *pgxpool.Pool) *ForumRepo {
 	return &ForumRepo{db}
 }
 
-const CreateForumQuery = `INSERT INTO forums (slug, title, user_nickname) VALUES($1, $2, $3)`
+const CreateForumQuery = `INSERT INTO forums (slug, title, user_nickname, parent, is_category) VALUES($1, $2, $3, NULLIF($4, ''), $5)`
+
 func (f *ForumRepo) CreateForum(forumInput *entity.Forum) error {
-	_, err := f.db.Exec(context.Background(), CreateForumQuery, forumInput.Slug, forumInput.Title,	forumInput.User)
+	_, err := f.db.Exec(context.Background(), CreateForumQuery, forumInput.Slug, forumInput.Title, forumInput.User,
+		forumInput.Parent, forumInput.IsCategory)
 	return err
 }
 
-const GetForumDetailsQuery = `SELECT slug, title, user_nickname, thread_count, post_count FROM forums WHERE slug = $1`
+const GetForumDetailsQuery = `SELECT slug, title, user_nickname, thread_count, post_count,
+		COALESCE(parent, ''), is_category, total_thread_count, total_post_count
+	FROM forums WHERE slug = $1`
+
 func (f *ForumRepo) GetForumDetails(slug string) (*entity.Forum, error) {
 	forum := &entity.Forum{}
 
@@ -30,39 +37,72 @@ func (f *ForumRepo) GetForumDetails(slug string) (*entity.Forum, error) {
 		&forum.Title,
 		&forum.User,
 		&forum.Threads,
-		&forum.Posts)
+		&forum.Posts,
+		&forum.Parent,
+		&forum.IsCategory,
+		&forum.TotalThreads,
+		&forum.TotalPosts)
 
+	if err == pgx.ErrNoRows {
+		return nil, entity.ForumNotExistError
+	}
 	if err != nil {
 		return nil, err
 	}
 	return forum, nil
 }
 
+const GetForumsQuery = `SELECT slug, title, user_nickname, thread_count, post_count,
+		COALESCE(parent, ''), is_category, total_thread_count, total_post_count
+	FROM forums ORDER BY path`
+
+// GetForums returns every forum, parents before their sub-forums
+func (f *ForumRepo) GetForums() ([]entity.Forum, error) {
+	rows, err := f.db.Query(context.Background(), GetForumsQuery)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	forums := make([]entity.Forum, 0)
+	for rows.Next() {
+		forum := entity.Forum{}
+		err = rows.Scan(&forum.Slug, &forum.Title, &forum.User, &forum.Threads, &forum.Posts,
+			&forum.Parent, &forum.IsCategory, &forum.TotalThreads, &forum.TotalPosts)
+		if err != nil {
+			return nil, err
+		}
+		forums = append(forums, forum)
+	}
+
+	return forums, nil
+}
+
 func (f *ForumRepo) GetForumUsers(slug string, limit int32, since string, order string, compare string) ([]entity.User, error) {
 	var query string
 	if since != "" {
 		if limit != 0 {
-			query = fmt.Sprintf(`SELECT u.about, u.email, u.fullname, u.nickname FROM users AS u
+			query = fmt.Sprintf(`SELECT u.about, u.email, u.fullname, u.nickname, u.avatar, u.karma FROM users AS u
 				JOIN forum_user AS fu ON u.nickname = fu.nickname
 				WHERE fu.forum_slug = '%s' AND fu.nickname %v '%s'
 				ORDER BY u.nickname %v
 				LIMIT %v`, slug, compare, since, order, limit)
 		} else {
 
-			query = fmt.Sprintf(`SELECT u.about, u.email, u.fullname, u.nickname FROM users AS u
+			query = fmt.Sprintf(`SELECT u.about, u.email, u.fullname, u.nickname, u.avatar, u.karma FROM users AS u
 				JOIN forum_user AS fu ON u.nickname = fu.nickname
 				WHERE fu.forum_slug = '%s' AND fu.nickname %v '%s'
 				ORDER BY u.nickname %v`, slug, compare, since, order)
 		}
 	} else {
 		if limit != 0 {
-			query = fmt.Sprintf(`SELECT u.about, u.email, u.fullname, u.nickname FROM users AS u
+			query = fmt.Sprintf(`SELECT u.about, u.email, u.fullname, u.nickname, u.avatar, u.karma FROM users AS u
 				JOIN forum_user AS fu ON u.nickname = fu.nickname
 				WHERE fu.forum_slug = '%s'
 				ORDER BY u.nickname %v
 				LIMIT %v`, slug, order, limit)
 		} else {
-			query = fmt.Sprintf(`SELECT u.about, u.email, u.fullname, u.nickname FROM users AS u
+			query = fmt.Sprintf(`SELECT u.about, u.email, u.fullname, u.nickname, u.avatar, u.karma FROM users AS u
 				JOIN forum_user AS fu ON u.nickname = fu.nickname
 				WHERE fu.forum_slug = '%s' 
 				ORDER BY u.nickname %v`, slug, order)
@@ -79,16 +119,47 @@ func (f *ForumRepo) GetForumUsers(slug string, limit int32, since string, order
 	users := make([]entity.User, 0, limit)
 	for rows.Next() {
 		user := entity.User{}
-		err = rows.Scan(&user.About, &user.Email, &user.Fullname, &user.Nickname)
+		err = rows.Scan(&user.About, &user.Email, &user.Fullname, &user.Nickname, &user.Avatar, &user.Karma)
 		if err != nil {
 			return nil, err // TODO: error handling
 		}
+		user.Avatar = avatarOrDefault(user.Avatar)
+		users = append(users, user)
+	}
+	return users, nil
+}
+
+// GetForumUsersByKarmaQuery pages by (karma, nickname), since is the nickname of the last user of the previous page
+const GetForumUsersByKarmaQuery = `SELECT u.about, u.email, u.fullname, u.nickname, u.avatar, u.karma FROM users AS u
+	JOIN forum_user AS fu ON u.nickname = fu.nickname
+	WHERE fu.forum_slug = $1 AND ($2::TEXT = '' OR (u.karma, u.nickname) %[1]v
+		((SELECT karma FROM users WHERE nickname = $2::CITEXT), $2::CITEXT))
+	ORDER BY u.karma %[2]v, u.nickname %[2]v
+	LIMIT NULLIF($3, 0)`
+
+func (f *ForumRepo) GetForumUsersByKarma(slug string, limit int32, since string, order string, compare string) ([]entity.User, error) {
+	query := fmt.Sprintf(GetForumUsersByKarmaQuery, compare, order)
+	rows, err := f.db.Query(context.Background(), query, slug, since, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	users := make([]entity.User, 0, limit)
+	for rows.Next() {
+		user := entity.User{}
+		err = rows.Scan(&user.About, &user.Email, &user.Fullname, &user.Nickname, &user.Avatar, &user.Karma)
+		if err != nil {
+			return nil, err
+		}
+		user.Avatar = avatarOrDefault(user.Avatar)
 		users = append(users, user)
 	}
 	return users, nil
 }
 
 const CheckForumQuery = `SELECT slug FROM forums WHERE slug = $1`
+
 func (f *ForumRepo) CheckForum(slug string) (string, error) {
 	err := f.db.QueryRow(context.Background(), CheckForumQuery, slug).Scan(&slug)
 
@@ -97,4 +168,106 @@ func (f *ForumRepo) CheckForum(slug string) (string, error) {
 	}
 
 	return slug, nil
-}
\ No newline at end of file
+}
+
+const DeleteForumRevisionsQuery = `DELETE FROM post_revisions WHERE post IN (SELECT id FROM posts WHERE forum = $1)`
+const DeleteForumReactionsQuery = `DELETE FROM post_reaction WHERE post IN (SELECT id FROM posts WHERE forum = $1)`
+const DeleteForumPostVotesQuery = `DELETE FROM post_vote WHERE post_id IN (SELECT id FROM posts WHERE forum = $1)`
+const DeleteForumNotificationsQuery = `DELETE FROM notifications WHERE forum = $1`
+const DeleteForumPostsQuery = `DELETE FROM posts WHERE forum = $1`
+const DeleteForumVotesQuery = `DELETE FROM thread_vote WHERE thread_id IN (SELECT id FROM threads WHERE forum = $1)`
+const DeleteForumThreadSubscriptionsQuery = `DELETE FROM thread_subscriptions WHERE thread_id IN (SELECT id FROM threads WHERE forum = $1)`
+const DeleteForumReadMarkersQuery = `DELETE FROM read_markers WHERE thread_id IN (SELECT id FROM threads WHERE forum = $1)`
+const DeleteForumSubscriptionsQuery = `DELETE FROM forum_subscriptions WHERE forum_slug = $1`
+const DeleteForumThreadsQuery = `DELETE FROM threads WHERE forum = $1`
+const DeleteForumUsersQuery = `DELETE FROM forum_user WHERE forum_slug = $1`
+const ResetForumCountersQuery = `UPDATE forums SET thread_count = 0, post_count = 0 WHERE slug = $1`
+const DeleteForumQuery = `DELETE FROM forums WHERE slug = $1`
+const HasSubForumsQuery = `SELECT EXISTS(SELECT 1 FROM forums WHERE parent = $1)`
+const LockForumQuery = `SELECT slug FROM forums WHERE slug = $1 FOR UPDATE`
+const GetForumThreadIDsQuery = `SELECT COALESCE(array_agg(id), '{}') FROM threads WHERE forum = $1`
+
+// DeleteForum removes the forum and everything posted in it in one transaction;
+// its counters are reset before the row goes so that the totals of its ancestors drop as well.
+// Sub-forums are checked under the forum lock, one created meanwhile fails the parent key on the final delete
+func (f *ForumRepo) DeleteForum(slug string, audit *entity.AuditEntry) error {
+	tx, err := f.db.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	err = tx.QueryRow(context.Background(), LockForumQuery, slug).Scan(&slug)
+	if err == pgx.ErrNoRows {
+		return entity.ForumNotExistError
+	}
+	if err != nil {
+		return err
+	}
+
+	var hasSubForums bool
+	err = tx.QueryRow(context.Background(), HasSubForumsQuery, slug).Scan(&hasSubForums)
+	if err != nil {
+		return err
+	}
+	if hasSubForums {
+		return entity.ForumHasSubForumsError
+	}
+
+	// stubs in other forums that redirect here would point nowhere
+	var threadIDs []int
+	err = tx.QueryRow(context.Background(), GetForumThreadIDsQuery, slug).Scan(&threadIDs)
+	if err != nil {
+		return err
+	}
+	err = deleteRedirectStubs(tx, threadIDs)
+	if err != nil {
+		return err
+	}
+
+	for _, query := range []string{
+		DeleteForumRevisionsQuery,
+		DeleteForumReactionsQuery,
+		DeleteForumPostVotesQuery,
+		DeleteForumNotificationsQuery,
+		DeleteForumPostsQuery,
+		DeleteForumVotesQuery,
+		DeleteForumThreadSubscriptionsQuery,
+		DeleteForumReadMarkersQuery,
+		DeleteForumSubscriptionsQuery,
+		DeleteForumThreadsQuery,
+		DeleteForumUsersQuery,
+		ResetForumCountersQuery,
+	} {
+		_, err = tx.Exec(context.Background(), query, slug)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(context.Background(), DeleteForumQuery, slug)
+	if sqlState(err) == foreignKeyViolation {
+		return entity.ForumHasSubForumsError
+	}
+	if err != nil {
+		return err
+	}
+
+	err = addAuditEntry(tx, audit, nil)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(context.Background())
+}
+
+const foreignKeyViolation = "23503"
+
+// sqlState returns the Postgres error code of err, or an empty string when it did not come from the server
+func sqlState(err error) string {
+	var pgErr interface{ SQLState() string }
+	if errors.As(err, &pgErr) {
+		return pgErr.SQLState()
+	}
+	return ""
+}
@@ -0,0 +1,50 @@
+package persistence
+
+import (
+	"context"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type IgnoreRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewIgnoreRepository(db *pgxpool.Pool) *IgnoreRepo {
+	return &IgnoreRepo{db: db}
+}
+
+const IgnoreUserQuery = `INSERT INTO user_ignores (nickname, ignored) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+
+func (i *IgnoreRepo) IgnoreUser(nickname string, ignored string) error {
+	_, err := i.db.Exec(context.Background(), IgnoreUserQuery, nickname, ignored)
+	return err
+}
+
+const UnignoreUserQuery = `DELETE FROM user_ignores WHERE nickname = $1 AND ignored = $2`
+
+func (i *IgnoreRepo) UnignoreUser(nickname string, ignored string) error {
+	_, err := i.db.Exec(context.Background(), UnignoreUserQuery, nickname, ignored)
+	return err
+}
+
+const GetIgnoredUsersQuery = `SELECT ignored FROM user_ignores WHERE nickname = $1 ORDER BY ignored`
+
+func (i *IgnoreRepo) GetIgnoredUsers(nickname string) ([]string, error) {
+	rows, err := i.db.Query(context.Background(), GetIgnoredUsersQuery, nickname)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ignored := make([]string, 0)
+	for rows.Next() {
+		var user string
+		err = rows.Scan(&user)
+		if err != nil {
+			return nil, err
+		}
+		ignored = append(ignored, user)
+	}
+
+	return ignored, nil
+}
@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const listenRetryDelay = time.Second
+
+type channelHandler struct {
+	handle      func(payload string)
+	reconnected func()
+}
+
+// Listener keeps one connection LISTENing on every registered NOTIFY channel and passes
+// payloads to the channel handlers. Every server instance runs its own listener,
+// so handlers see changes committed through any of them.
+type Listener struct {
+	db       *pgxpool.Pool
+	handlers map[string]channelHandler
+}
+
+func NewListener(db *pgxpool.Pool) *Listener {
+	return &Listener{
+		db:       db,
+		handlers: make(map[string]channelHandler),
+	}
+}
+
+// Handle registers channel handler, reconnected is called after the connection was
+// re-established since notifications could be missed meanwhile. Must be called before Listen.
+func (l *Listener) Handle(channel string, handle func(payload string), reconnected func()) {
+	l.handlers[channel] = channelHandler{handle: handle, reconnected: reconnected}
+}
+
+// Listen blocks until ctx is done, reconnecting whenever the listening connection is lost
+func (l *Listener) Listen(ctx context.Context) {
+	for ctx.Err() == nil {
+		err := l.listen(ctx)
+		if err != nil && ctx.Err() == nil {
+			log.Printf("listener: %s", err.Error())
+			time.Sleep(listenRetryDelay)
+		}
+
+		for _, handler := range l.handlers {
+			if handler.reconnected != nil {
+				handler.reconnected()
+			}
+		}
+	}
+}
+
+func (l *Listener) listen(ctx context.Context) error {
+	conn, err := l.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// listening connection must not be reused by regular queries
+	defer func() {
+		_ = conn.Conn().Close(context.Background())
+		conn.Release()
+	}()
+
+	for channel := range l.handlers {
+		_, err = conn.Exec(ctx, "LISTEN "+channel)
+		if err != nil {
+			return err
+		}
+	}
+
+	for {
+		notification, err := conn.Conn().WaitForNotification(ctx)
+		if err != nil {
+			return err
+		}
+
+		handler, ok := l.handlers[notification.Channel]
+		if ok {
+			handler.handle(notification.Payload)
+		}
+	}
+}
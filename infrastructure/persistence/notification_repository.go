@@ -0,0 +1,115 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"forum/domain/entity"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type NotificationRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewNotificationRepository(db *pgxpool.Pool) *NotificationRepo {
+	return &NotificationRepo{db: db}
+}
+
+// GetNotifications also hides notifications raised by users ignored after the fact
+func (n *NotificationRepo) GetNotifications(nickname string, limit int32, since int, desc bool, unread bool) ([]entity.Notification, error) {
+	query := `SELECT id, type, actor, forum, thread, post, created, is_read FROM notifications WHERE nickname = $1
+		AND NOT EXISTS (SELECT 1 FROM user_ignores WHERE user_ignores.nickname = $1 AND ignored = actor)`
+	order := "ASC"
+	compare := ">"
+	if desc {
+		order = "DESC"
+		compare = "<"
+	}
+
+	if unread {
+		query += " AND NOT is_read"
+	}
+
+	if since != 0 {
+		query += fmt.Sprintf(" AND id %v %d", compare, since)
+	}
+
+	query += fmt.Sprintf(" ORDER BY id %v", order)
+	if limit != 0 {
+		query += fmt.Sprintf(" LIMIT %d", limit)
+	}
+
+	rows, err := n.db.Query(context.Background(), query, nickname)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	notifications := make([]entity.Notification, 0, limit)
+	for rows.Next() {
+		notification := entity.Notification{}
+		err = rows.Scan(
+			&notification.ID,
+			&notification.Type,
+			&notification.Actor,
+			&notification.Forum,
+			&notification.Thread,
+			&notification.Post,
+			&notification.Created,
+			&notification.IsRead)
+		if err != nil {
+			return nil, err
+		}
+		notifications = append(notifications, notification)
+	}
+
+	return notifications, nil
+}
+
+const GetNotificationQuery = `SELECT id, type, actor, forum, thread, post, created, is_read FROM notifications
+		WHERE nickname = $1 AND id = $2`
+
+func (n *NotificationRepo) GetNotification(nickname string, ID int) (*entity.Notification, error) {
+	notification := &entity.Notification{}
+	err := n.db.QueryRow(context.Background(), GetNotificationQuery, nickname, ID).Scan(
+		&notification.ID,
+		&notification.Type,
+		&notification.Actor,
+		&notification.Forum,
+		&notification.Thread,
+		&notification.Post,
+		&notification.Created,
+		&notification.IsRead)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.NotificationNotFoundError
+		}
+		return nil, err
+	}
+
+	return notification, nil
+}
+
+const MarkNotificationReadQuery = `UPDATE notifications SET is_read = TRUE WHERE nickname = $1 AND id = $2`
+
+func (n *NotificationRepo) MarkNotificationRead(nickname string, ID int) error {
+	tag, err := n.db.Exec(context.Background(), MarkNotificationReadQuery, nickname, ID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return entity.NotificationNotFoundError
+	}
+
+	return nil
+}
+
+const MarkAllNotificationsReadQuery = `UPDATE notifications SET is_read = TRUE WHERE nickname = $1 AND NOT is_read`
+
+func (n *NotificationRepo) MarkAllNotificationsRead(nickname string) error {
+	_, err := n.db.Exec(context.Background(), MarkAllNotificationsReadQuery, nickname)
+	return err
+}
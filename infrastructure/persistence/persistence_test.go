@@ -0,0 +1,419 @@
+package persistence
+
+import (
+	"context"
+	"forum/domain/entity"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+// testDB connects to TEST_DATABASE_URL, a throwaway database loaded from db.sql; every test starts by emptying it
+func testDB(t *testing.T) *pgxpool.Pool {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := pgxpool.Connect(context.Background(), url)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(db.Close)
+
+	err = NewServiceRepository(db).ClearAllDate(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func createUsers(t *testing.T, db *pgxpool.Pool, nicknames ...string) {
+	users := NewUserRepository(db)
+	for _, nickname := range nicknames {
+		err := users.CreateUser(&entity.User{Nickname: nickname, Fullname: nickname, Email: nickname + "@example.com"})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func createForum(t *testing.T, db *pgxpool.Pool, slug string, author string) {
+	err := NewForumRepository(db).CreateForum(&entity.Forum{Slug: slug, Title: slug, User: author})
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func createThread(t *testing.T, db *pgxpool.Pool, forum string, author string) *entity.Thread {
+	thread := &entity.Thread{
+		Forum:   forum,
+		Author:  author,
+		Title:   "title",
+		Message: "message",
+		Created: strfmt.DateTime(time.Now()),
+	}
+	err := NewThreadRepository(db).CreateThread(thread)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return thread
+}
+
+func createPosts(t *testing.T, db *pgxpool.Pool, thread *entity.Thread, authors ...string) []entity.Post {
+	posts := make([]entity.Post, len(authors))
+	for i, author := range authors {
+		posts[i] = entity.Post{Author: author, Message: "post"}
+	}
+	err := NewThreadRepository(db).CreatePosts(thread, posts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return posts
+}
+
+func TestForumCountersFollowThreadsAndPosts(t *testing.T) {
+	db := testDB(t)
+	createUsers(t, db, "alice", "bob", "carol")
+	createForum(t, db, "go", "alice")
+
+	thread := createThread(t, db, "go", "bob")
+	createPosts(t, db, thread, "bob", "carol", "carol")
+	createThread(t, db, "go", "bob")
+
+	forum, err := NewForumRepository(db).GetForumDetails("go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if forum.Threads != 2 || forum.Posts != 3 {
+		t.Errorf("forum counts %v threads and %v posts, want 2 and 3", forum.Threads, forum.Posts)
+	}
+
+	users, err := NewForumRepository(db).GetForumUsers("go", 10, "", "ASC", ">")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 2 || users[0].Nickname != "bob" || users[1].Nickname != "carol" {
+		t.Errorf("forum users are %v, want bob and carol", users)
+	}
+}
+
+func TestThreadDeleteRecomputesForumCounters(t *testing.T) {
+	db := testDB(t)
+	createUsers(t, db, "alice", "bob")
+	createForum(t, db, "go", "alice")
+
+	thread := createThread(t, db, "go", "alice")
+	createPosts(t, db, thread, "bob", "bob")
+	kept := createThread(t, db, "go", "alice")
+	createPosts(t, db, kept, "alice")
+
+	err := NewThreadRepository(db).DeleteThread(thread, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	forum, err := NewForumRepository(db).GetForumDetails("go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if forum.Threads != 1 || forum.Posts != 1 {
+		t.Errorf("forum counts %v threads and %v posts, want 1 and 1", forum.Threads, forum.Posts)
+	}
+
+	users, err := NewForumRepository(db).GetForumUsers("go", 10, "", "ASC", ">")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 1 || users[0].Nickname != "alice" {
+		t.Errorf("forum users are %v, want only alice", users)
+	}
+
+	err = NewThreadRepository(db).DeleteThread(thread, nil)
+	if err != entity.ThreadNotFoundError {
+		t.Errorf("deleting the thread again: got %v", err)
+	}
+}
+
+func TestVotesCountOncePerUser(t *testing.T) {
+	db := testDB(t)
+	createUsers(t, db, "alice", "bob")
+	createForum(t, db, "go", "alice")
+	thread := createThread(t, db, "go", "alice")
+	post := createPosts(t, db, thread, "alice")[0]
+
+	threads := NewThreadRepository(db)
+	for _, step := range []struct {
+		nickname string
+		voice    int
+		votes    int
+	}{
+		{"alice", 1, 1},
+		{"alice", 1, 1},
+		{"alice", -1, -1},
+		{"bob", -1, -2},
+	} {
+		voted, err := threads.VoteForThread(&entity.Vote{Nickname: step.nickname, Voice: step.voice, ID: thread.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if voted.Votes != step.votes {
+			t.Errorf("after %v voted %v the thread has %v votes, want %v", step.nickname, step.voice, voted.Votes, step.votes)
+		}
+	}
+
+	err := threads.RetractThreadVote("bob", thread.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	voted, err := threads.GetThreadByID(thread.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if voted.Votes != -1 {
+		t.Errorf("after bob retracted the thread has %v votes, want -1", voted.Votes)
+	}
+
+	posts := NewPostRepository(db)
+	for _, step := range []struct {
+		nickname string
+		voice    int
+		votes    int
+	}{
+		{"bob", 1, 1},
+		{"bob", -1, -1},
+		{"alice", -1, -2},
+	} {
+		votedPost, err := posts.VoteForPost(&entity.Vote{Nickname: step.nickname, Voice: step.voice, ID: post.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if votedPost.Votes != step.votes {
+			t.Errorf("after %v voted %v the post has %v votes, want %v", step.nickname, step.voice, votedPost.Votes, step.votes)
+		}
+	}
+}
+
+func TestConcurrentFirstVotesAreCountedOnce(t *testing.T) {
+	db := testDB(t)
+	createUsers(t, db, "alice", "bob")
+	createForum(t, db, "go", "alice")
+	thread := createThread(t, db, "go", "alice")
+	post := createPosts(t, db, thread, "alice")[0]
+
+	threads := NewThreadRepository(db)
+	posts := NewPostRepository(db)
+	vote := &entity.Vote{Nickname: "bob", Voice: 1}
+
+	// a double click sends the same first vote on several connections at once
+	var wg sync.WaitGroup
+	errs := make(chan error, 16)
+	for i := 0; i < 8; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			threadVote := *vote
+			threadVote.ID = thread.ID
+			_, err := threads.VoteForThread(&threadVote)
+			errs <- err
+		}()
+		go func() {
+			defer wg.Done()
+			postVote := *vote
+			postVote.ID = post.ID
+			_, err := posts.VoteForPost(&postVote)
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	voted, err := threads.GetThreadByID(thread.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if voted.Votes != 1 {
+		t.Errorf("the thread has %v votes, want 1", voted.Votes)
+	}
+
+	votedPost, err := posts.VoteForPost(&entity.Vote{Nickname: "bob", Voice: 1, ID: post.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if votedPost.Votes != 1 {
+		t.Errorf("the post has %v votes, want 1", votedPost.Votes)
+	}
+}
+
+func TestFeedPagesWithoutRepeatsOrGaps(t *testing.T) {
+	db := testDB(t)
+	createUsers(t, db, "alice", "bob", "carol")
+	createForum(t, db, "go", "alice")
+
+	subscriptions := NewSubscriptionRepository(db)
+	err := subscriptions.SubscribeForum("alice", "go")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createThread(t, db, "go", "bob")
+	createThread(t, db, "go", "carol")
+	followed := createThread(t, db, "go", "alice")
+	err = subscriptions.SubscribeThread("alice", followed.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// one batch shares its created time, only type and id tell its posts apart
+	createPosts(t, db, followed, "bob", "bob", "carol", "alice", "bob")
+
+	err = NewIgnoreRepository(db).IgnoreUser("alice", "carol")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	seen := make(map[string]bool)
+	query := &entity.FeedQuery{Limit: 2}
+	for page := 0; ; page++ {
+		if page > 10 {
+			t.Fatal("feed does not end")
+		}
+		items, err := subscriptions.GetFeed("alice", query)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, item := range items {
+			var key string
+			var author string
+			var created time.Time
+			if item.Type == entity.FeedItemThread {
+				key, author, created = "thread "+strconv.Itoa(item.Thread.ID), item.Thread.Author, time.Time(item.Thread.Created)
+				query.AfterID = item.Thread.ID
+			} else {
+				key, author, created = "post "+strconv.Itoa(item.Post.ID), item.Post.Author, time.Time(item.Post.Created)
+				query.AfterID = item.Post.ID
+			}
+			query.AfterCreated, query.AfterType = created, item.Type
+
+			if seen[key] {
+				t.Errorf("%v is served twice", key)
+			}
+			seen[key] = true
+			if author != "bob" {
+				t.Errorf("%v by %v is in the feed", key, author)
+			}
+		}
+
+		if len(items) < int(query.Limit) {
+			break
+		}
+	}
+
+	// bob's thread and three of his posts
+	if len(seen) != 4 {
+		t.Errorf("feed served %v items, want 4: %v", len(seen), seen)
+	}
+}
+
+func TestMoveBackReplacesRedirectStub(t *testing.T) {
+	db := testDB(t)
+	createUsers(t, db, "alice")
+	createForum(t, db, "go", "alice")
+	createForum(t, db, "rust", "alice")
+	thread := createThread(t, db, "go", "alice")
+
+	threads := NewThreadRepository(db)
+	stub := &entity.Thread{Author: "alice", Created: thread.Created, Forum: "go", Title: "title", Message: "moved", MovedTo: thread.ID}
+	err := threads.MoveThread(thread, "rust", stub, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	thread.Forum = "rust"
+	err = threads.MoveThread(thread, "go", nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = threads.GetThreadByID(stub.ID)
+	if err != entity.ThreadNotFoundError {
+		t.Errorf("the stub left in go: got %v", err)
+	}
+	forum, err := NewForumRepository(db).GetForumDetails("go")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if forum.Threads != 1 {
+		t.Errorf("go counts %v threads, want 1", forum.Threads)
+	}
+
+	err = threads.MoveThread(&entity.Thread{ID: thread.ID + 100, Forum: "go"}, "rust", nil, nil)
+	if err != entity.ThreadNotFoundError {
+		t.Errorf("moving a missing thread: got %v", err)
+	}
+}
+
+func TestPinnedThreadsCountAgainstLimit(t *testing.T) {
+	db := testDB(t)
+	createUsers(t, db, "alice")
+	createForum(t, db, "go", "alice")
+	threads := NewThreadRepository(db)
+
+	pinned := true
+	for i := 0; i < 2; i++ {
+		thread := createThread(t, db, "go", "alice")
+		err := threads.UpdateThreadState(thread.ID, &entity.ThreadStateInput{Pinned: &pinned}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 3; i++ {
+		createThread(t, db, "go", "alice")
+	}
+
+	for limit, want := range map[int32]int{1: 1, 2: 2, 3: 3, 10: 5} {
+		page, err := threads.GetThreadsByForumSlug("go", limit, "", false, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page) != want {
+			t.Errorf("the first page with limit %v has %v threads, want %v", limit, len(page), want)
+		}
+		for i, thread := range page {
+			if thread.Pinned != (i < 2) {
+				t.Errorf("thread %v of the first page with limit %v: pinned is %v", i, limit, thread.Pinned)
+			}
+		}
+	}
+}
+
+func TestUnreadCountsSkipDeletedPosts(t *testing.T) {
+	db := testDB(t)
+	createUsers(t, db, "alice", "bob")
+	createForum(t, db, "go", "alice")
+	thread := createThread(t, db, "go", "alice")
+	posts := createPosts(t, db, thread, "alice", "alice", "alice")
+
+	err := NewPostRepository(db).DeletePost(posts[1].ID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unread, err := NewThreadRepository(db).GetUnreadCounts("bob", []int{thread.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if unread[thread.ID] != 2 {
+		t.Errorf("bob has %v unread posts, want 2", unread[thread.ID])
+	}
+}
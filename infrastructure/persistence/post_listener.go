@@ -0,0 +1,81 @@
+package persistence
+
+import (
+	"strconv"
+	"sync"
+)
+
+const threadPostsChannel = "thread_posts"
+
+// PostListener receives thread ids from the thread_posts NOTIFY channel,
+// which is fed by the posts insert trigger, and wakes up subscribers of those threads
+type PostListener struct {
+	mu          sync.Mutex
+	subscribers map[int]map[chan struct{}]struct{}
+}
+
+func NewPostListener(listener *Listener) *PostListener {
+	l := &PostListener{
+		subscribers: make(map[int]map[chan struct{}]struct{}),
+	}
+	// notifications could be missed while reconnecting
+	listener.Handle(threadPostsChannel, l.handle, l.wakeAll)
+	return l
+}
+
+// Subscribe returns a channel signalled whenever new posts are committed to the thread,
+// signals are coalesced so subscriber has to fetch everything after its last seen post
+func (l *PostListener) Subscribe(threadID int) (<-chan struct{}, func()) {
+	wake := make(chan struct{}, 1)
+
+	l.mu.Lock()
+	if l.subscribers[threadID] == nil {
+		l.subscribers[threadID] = make(map[chan struct{}]struct{})
+	}
+	l.subscribers[threadID][wake] = struct{}{}
+	l.mu.Unlock()
+
+	unsubscribe := func() {
+		l.mu.Lock()
+		delete(l.subscribers[threadID], wake)
+		if len(l.subscribers[threadID]) == 0 {
+			delete(l.subscribers, threadID)
+		}
+		l.mu.Unlock()
+	}
+
+	return wake, unsubscribe
+}
+
+func (l *PostListener) handle(payload string) {
+	threadID, err := strconv.Atoi(payload)
+	if err != nil {
+		return
+	}
+	l.wake(threadID)
+}
+
+func (l *PostListener) wake(threadID int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for wake := range l.subscribers[threadID] {
+		select {
+		case wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (l *PostListener) wakeAll() {
+	l.mu.Lock()
+	threadIDs := make([]int, 0, len(l.subscribers))
+	for threadID := range l.subscribers {
+		threadIDs = append(threadIDs, threadID)
+	}
+	l.mu.Unlock()
+
+	for _, threadID := range threadIDs {
+		l.wake(threadID)
+	}
+}
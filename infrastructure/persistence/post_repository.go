@@ -3,6 +3,7 @@ package persistence
 import (
 	"context"
 	"forum/domain/entity"
+	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 )
 
@@ -14,7 +15,16 @@ func NewPostRepository(db *pgxpool.Pool) *PostRepo {
 	return &PostRepo{db: db}
 }
 
-const GetPostDetailsQuery = `SELECT author, created, forum, id, msg, thread, isEdited, parent FROM posts WHERE id = $1`
+// tombstone hides the message of a soft-deleted post, keeping its place in the thread tree
+func tombstone(post *entity.Post) {
+	if post.IsDeleted {
+		post.Message = entity.DeletedPostMessage
+		post.Reactions = nil
+	}
+}
+
+const GetPostDetailsQuery = `SELECT author, created, forum, id, msg, thread, isEdited, parent, isDeleted, reactions, votes FROM posts WHERE id = $1`
+
 func (p *PostRepo) GetPostDetails(postID int) (*entity.Post, error) {
 	post := &entity.Post{}
 	err := p.db.QueryRow(context.Background(), GetPostDetailsQuery, postID).Scan(
@@ -25,20 +35,101 @@ func (p *PostRepo) GetPostDetails(postID int) (*entity.Post, error) {
 		&post.Message,
 		&post.Thread,
 		&post.IsEdited,
-		&post.Parent)
+		&post.Parent,
+		&post.IsDeleted,
+		&post.Reactions,
+		&post.Votes)
+
+	if err == pgx.ErrNoRows {
+		return nil, entity.PostNotFoundError
+	}
+	if err != nil {
+		return nil, err
+	}
+	tombstone(post)
+
+	return post, nil
+}
+
+const ChangePostMessageQuery = `UPDATE posts SET msg = $1, isEdited = true, editor = $3
+	          WHERE id = $2 AND NOT isDeleted
+	          RETURNING author, created, forum, id, msg, thread, isEdited, parent, reactions, votes`
+
+func (p *PostRepo) ChangePostMessage(post *entity.Post, audit *entity.AuditEntry) (*entity.Post, error) {
+	tx, err := p.db.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+
+	err = tx.QueryRow(context.Background(), ChangePostMessageQuery, post.Message, post.ID, post.Author).Scan(
+		&post.Author,
+		&post.Created,
+		&post.Forum,
+		&post.ID,
+		&post.Message,
+		&post.Thread,
+		&post.IsEdited,
+		&post.Parent,
+		&post.Reactions,
+		&post.Votes)
 
 	if err != nil {
 		return nil, err
 	}
 
+	err = addAuditEntry(tx, audit, post)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		return nil, err
+	}
 	return post, nil
 }
 
-const ChangePostMessageQuery = `UPDATE posts SET msg = $1, isEdited = true 
-	          WHERE id = $2
-	          RETURNING author, created, forum, id, msg, thread, isEdited, parent`
-func (p *PostRepo) ChangePostMessage(post *entity.Post) (*entity.Post, error) {
-	err := p.db.QueryRow(context.Background(), ChangePostMessageQuery, post.Message, post.ID).Scan(
+const DeletePostQuery = `UPDATE posts SET isDeleted = TRUE WHERE id = $1`
+
+func (p *PostRepo) DeletePost(postID int, audit *entity.AuditEntry) error {
+	tx, err := p.db.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	tag, err := tx.Exec(context.Background(), DeletePostQuery, postID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return entity.PostNotFoundError
+	}
+
+	err = addAuditEntry(tx, audit, nil)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(context.Background())
+}
+
+const RestorePostQuery = `UPDATE posts SET isDeleted = FALSE
+	          WHERE id = $1
+	          RETURNING author, created, forum, id, msg, thread, isEdited, parent, reactions, votes`
+
+// RestorePost puts the audit entry into the forum of the post
+func (p *PostRepo) RestorePost(postID int, audit *entity.AuditEntry) (*entity.Post, error) {
+	tx, err := p.db.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+
+	post := &entity.Post{}
+	err = tx.QueryRow(context.Background(), RestorePostQuery, postID).Scan(
 		&post.Author,
 		&post.Created,
 		&post.Forum,
@@ -46,10 +137,79 @@ func (p *PostRepo) ChangePostMessage(post *entity.Post) (*entity.Post, error) {
 		&post.Message,
 		&post.Thread,
 		&post.IsEdited,
-		&post.Parent)
+		&post.Parent,
+		&post.Reactions,
+		&post.Votes)
 
+	if err == pgx.ErrNoRows {
+		return nil, entity.PostNotFoundError
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if audit != nil {
+		audit.Forum = post.Forum
+	}
+	err = addAuditEntry(tx, audit, post)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(context.Background())
 	if err != nil {
 		return nil, err
 	}
 	return post, nil
 }
+
+const GetPostRevisionsQuery = `SELECT revision, editor, msg, created FROM post_revisions WHERE post = $1 ORDER BY revision`
+
+func (p *PostRepo) GetPostRevisions(postID int) ([]entity.PostRevision, error) {
+	rows, err := p.db.Query(context.Background(), GetPostRevisionsQuery, postID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	revisions := make([]entity.PostRevision, 0)
+	for rows.Next() {
+		revision := entity.PostRevision{}
+		err = rows.Scan(&revision.Revision, &revision.Editor, &revision.Message, &revision.Created)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, nil
+}
+
+const AddPostReactionQuery = `INSERT INTO post_reaction (post, nickname, reaction) VALUES ($1, $2, $3)
+	          ON CONFLICT DO NOTHING`
+
+func (p *PostRepo) AddPostReaction(postID int, nickname string, reaction string) error {
+	_, err := p.db.Exec(context.Background(), AddPostReactionQuery, postID, nickname, reaction)
+	return err
+}
+
+const RemovePostReactionQuery = `DELETE FROM post_reaction WHERE post = $1 AND nickname = $2 AND reaction = $3`
+
+func (p *PostRepo) RemovePostReaction(postID int, nickname string, reaction string) error {
+	_, err := p.db.Exec(context.Background(), RemovePostReactionQuery, postID, nickname, reaction)
+	return err
+}
+
+// a concurrent first vote of the same user turns into the update instead of failing on the unique constraint
+const VotePostQuery = `INSERT INTO post_vote (nickname, post_id, vote) VALUES($1, $2, $3)
+	ON CONFLICT (nickname, post_id) DO UPDATE SET vote = EXCLUDED.vote WHERE post_vote.vote <> EXCLUDED.vote`
+
+// VoteForPost stores the voice of vote.Nickname on post vote.ID; the score itself is kept by the post_vote triggers
+func (p *PostRepo) VoteForPost(vote *entity.Vote) (*entity.Post, error) {
+	_, err := p.db.Exec(context.Background(), VotePostQuery, vote.Nickname, vote.ID, vote.Voice)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.GetPostDetails(vote.ID)
+}
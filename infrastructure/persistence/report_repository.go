@@ -0,0 +1,164 @@
+package persistence
+
+import (
+	"context"
+	"forum/domain/entity"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"strconv"
+)
+
+type ReportRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewReportRepository(db *pgxpool.Pool) *ReportRepo {
+	return &ReportRepo{db: db}
+}
+
+const CreateReportQuery = `INSERT INTO reports (target_type, target_id, forum, author, reporter, reason, comment)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (target_type, target_id, reporter) WHERE resolution IS NULL DO NOTHING
+		RETURNING id, created`
+
+func (r *ReportRepo) CreateReport(report *entity.Report) error {
+	err := r.db.QueryRow(context.Background(), CreateReportQuery,
+		report.Type, report.Target, report.Forum, report.Author, report.Reporter, report.Reason, report.Comment,
+	).Scan(&report.ID, &report.Created)
+
+	if err == pgx.ErrNoRows {
+		return entity.ReportAlreadyExistsError
+	}
+	return err
+}
+
+const GetReportQueueQuery = `SELECT target_type, target_id, MIN(author), COUNT(*) AS reports,
+		array_agg(DISTINCT reason), MIN(created), MAX(created)
+		FROM reports
+		WHERE forum = $1 AND resolution IS NULL
+		GROUP BY target_type, target_id
+		ORDER BY reports DESC, MAX(created) DESC
+		LIMIT $2`
+
+func (r *ReportRepo) GetReportQueue(forum string, limit int32) ([]entity.ReportQueueItem, error) {
+	rows, err := r.db.Query(context.Background(), GetReportQueueQuery, forum, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	queue := make([]entity.ReportQueueItem, 0, limit)
+	for rows.Next() {
+		item := entity.ReportQueueItem{}
+		err = rows.Scan(&item.Type, &item.Target, &item.Author, &item.Reports, &item.Reasons,
+			&item.FirstReported, &item.LastReported)
+		if err != nil {
+			return nil, err
+		}
+		queue = append(queue, item)
+	}
+
+	return queue, nil
+}
+
+// LockOpenReportsQuery makes a second moderator resolving the same target wait and then find no open reports
+const LockOpenReportsQuery = `SELECT id FROM reports
+		WHERE target_type = $1 AND target_id = $2 AND forum = $3 AND resolution IS NULL
+		FOR UPDATE`
+const InsertReportResolutionQuery = `INSERT INTO report_resolutions (target_type, target_id, forum, action, note, moderator, reports)
+		VALUES ($1, $2, $3, $4, $5, $6, 0)
+		RETURNING id, created`
+const CloseReportsQuery = `UPDATE reports SET resolution = $1
+		WHERE target_type = $2 AND target_id = $3 AND forum = $4 AND resolution IS NULL`
+const SetResolutionReportsQuery = `UPDATE report_resolutions SET reports = $1 WHERE id = $2`
+
+// ResolveReports applies the action, records the resolution and attaches every open report on its target to it,
+// all in one transaction; action is nil when the reports are dismissed
+func (r *ReportRepo) ResolveReports(resolution *entity.ReportResolution, action *entity.ResolutionAction, audit *entity.AuditEntry) error {
+	tx, err := r.db.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	rows, err := tx.Query(context.Background(), LockOpenReportsQuery, resolution.Type, resolution.Target, resolution.Forum)
+	if err != nil {
+		return err
+	}
+	open := 0
+	for rows.Next() {
+		open++
+	}
+	rows.Close()
+	if rows.Err() != nil {
+		return rows.Err()
+	}
+	if open == 0 {
+		return entity.ReportNotFoundError
+	}
+
+	if action != nil {
+		err = applyResolutionAction(tx, resolution, action)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = tx.QueryRow(context.Background(), InsertReportResolutionQuery,
+		resolution.Type, resolution.Target, resolution.Forum, resolution.Action, resolution.Note, resolution.Moderator,
+	).Scan(&resolution.ID, &resolution.Created)
+	if err != nil {
+		return err
+	}
+
+	tag, err := tx.Exec(context.Background(), CloseReportsQuery,
+		resolution.ID, resolution.Type, resolution.Target, resolution.Forum)
+	if err != nil {
+		return err
+	}
+	resolution.Reports = int(tag.RowsAffected())
+
+	_, err = tx.Exec(context.Background(), SetResolutionReportsQuery, resolution.Reports, resolution.ID)
+	if err != nil {
+		return err
+	}
+
+	err = addAuditEntry(tx, audit, resolution)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(context.Background())
+}
+
+func applyResolutionAction(tx pgx.Tx, resolution *entity.ReportResolution, action *entity.ResolutionAction) error {
+	switch resolution.Action {
+	case entity.ResolutionHidePost:
+		_, err := tx.Exec(context.Background(), DeletePostQuery, resolution.Target)
+		if err != nil {
+			return err
+		}
+		return addAuditEntry(tx, action.Audit, nil)
+	case entity.ResolutionLockThread:
+		locked := true
+		thread := &entity.Thread{}
+		err := scanThread(tx.QueryRow(context.Background(), UpdateThreadStateQuery, &locked, (*bool)(nil), (*bool)(nil), action.Thread), thread)
+		if err != nil {
+			return err
+		}
+		return addAuditEntry(tx, action.Audit, thread)
+	case entity.ResolutionBanAuthor:
+		ban := action.Ban
+		err := tx.QueryRow(context.Background(), CreateBanQuery,
+			ban.Nickname, ban.Forum, ban.Reason, ban.IssuedBy, ban.Expires,
+		).Scan(&ban.ID, &ban.Created)
+		if err != nil {
+			return err
+		}
+		if action.Audit != nil {
+			action.Audit.Target = strconv.Itoa(ban.ID)
+		}
+		return addAuditEntry(tx, action.Audit, ban)
+	}
+	return entity.UnknownResolutionError
+}
@@ -0,0 +1,119 @@
+package persistence
+
+import (
+	"context"
+	"forum/domain/entity"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type RoleRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewRoleRepository(db *pgxpool.Pool) *RoleRepo {
+	return &RoleRepo{db: db}
+}
+
+// an active global ban outranks a global role, which outranks moderation of the particular forum
+const GetRoleQuery = `SELECT COALESCE(
+		(SELECT 'banned' FROM bans WHERE nickname = $1 AND forum IS NULL AND lifted IS NULL
+			AND (expires IS NULL OR expires > now()) LIMIT 1),
+		(SELECT role FROM global_roles WHERE nickname = $1),
+		CASE WHEN EXISTS(SELECT 1 FROM forum_moderators WHERE forum_slug = $2 AND nickname = $1)
+			THEN 'moderator' ELSE 'member' END)`
+
+func (r *RoleRepo) GetRole(nickname string, forum string) (string, error) {
+	var role string
+	err := r.db.QueryRow(context.Background(), GetRoleQuery, nickname, forum).Scan(&role)
+	if err != nil {
+		return "", err
+	}
+
+	return role, nil
+}
+
+const GetForumModeratorsQuery = `SELECT nickname FROM forum_moderators WHERE forum_slug = $1 ORDER BY nickname`
+
+func (r *RoleRepo) GetForumModerators(forum string) ([]string, error) {
+	rows, err := r.db.Query(context.Background(), GetForumModeratorsQuery, forum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	moderators := make([]string, 0)
+	for rows.Next() {
+		var nickname string
+		err = rows.Scan(&nickname)
+		if err != nil {
+			return nil, err
+		}
+		moderators = append(moderators, nickname)
+	}
+
+	return moderators, nil
+}
+
+const AddForumModeratorQuery = `INSERT INTO forum_moderators (forum_slug, nickname) VALUES ($1, $2)
+		ON CONFLICT DO NOTHING`
+
+// AddForumModerator returns the moderators of the forum after the change
+func (r *RoleRepo) AddForumModerator(forum string, nickname string, audit *entity.AuditEntry) ([]string, error) {
+	err := r.db.QueryRow(context.Background(), CheckUserExistQuery, nickname).Scan(&nickname)
+	if err == pgx.ErrNoRows {
+		return nil, entity.UserDoesntExistsError
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.changeForumModerators(AddForumModeratorQuery, forum, nickname, audit)
+}
+
+const RemoveForumModeratorQuery = `DELETE FROM forum_moderators WHERE forum_slug = $1 AND nickname = $2`
+
+func (r *RoleRepo) RemoveForumModerator(forum string, nickname string, audit *entity.AuditEntry) ([]string, error) {
+	return r.changeForumModerators(RemoveForumModeratorQuery, forum, nickname, audit)
+}
+
+func (r *RoleRepo) changeForumModerators(query string, forum string, nickname string, audit *entity.AuditEntry) ([]string, error) {
+	tx, err := r.db.Begin(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), query, forum, nickname)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := tx.Query(context.Background(), GetForumModeratorsQuery, forum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	moderators := make([]string, 0)
+	for rows.Next() {
+		var moderator string
+		err = rows.Scan(&moderator)
+		if err != nil {
+			return nil, err
+		}
+		moderators = append(moderators, moderator)
+	}
+	rows.Close()
+
+	err = addAuditEntry(tx, audit, &entity.ForumModerators{Forum: forum, Moderators: moderators})
+	if err != nil {
+		return nil, err
+	}
+
+	err = tx.Commit(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return moderators, nil
+}
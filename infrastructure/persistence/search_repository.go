@@ -0,0 +1,104 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"forum/domain/entity"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+const searchConfig = "english"
+const searchHeadlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=30, MinWords=10"
+
+type SearchRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSearchRepository(db *pgxpool.Pool) *SearchRepo {
+	return &SearchRepo{db: db}
+}
+
+// searchFilters appends forum, author and cursor conditions of query to the ranked subquery
+func searchFilters(query *entity.SearchQuery, args []interface{}) (string, string, []interface{}) {
+	var filters, cursor string
+	if query.Forum != "" {
+		args = append(args, query.Forum)
+		filters += fmt.Sprintf(" AND s.forum = $%d", len(args))
+	}
+	if query.Author != "" {
+		args = append(args, query.Author)
+		filters += fmt.Sprintf(" AND s.author = $%d", len(args))
+	}
+	if query.AfterID != 0 {
+		args = append(args, query.AfterRank, query.AfterID)
+		cursor = fmt.Sprintf(" WHERE (r.rank, r.id) < ($%d::REAL, $%d)", len(args)-1, len(args))
+	}
+	return filters, cursor, args
+}
+
+func (s *SearchRepo) SearchPosts(query *entity.SearchQuery) ([]entity.SearchResult, error) {
+	filters, cursor, args := searchFilters(query, []interface{}{query.Text})
+
+	sqlQuery := fmt.Sprintf(`SELECT r.id, r.thread, r.forum, r.author, r.created, r.rank,
+			ts_headline('%[1]s', r.msg, r.q, '%[2]s')
+		FROM (SELECT * FROM (
+				SELECT s.id, s.thread, s.forum, s.author, s.created, s.msg, q, ts_rank(s.search, q) AS rank
+				FROM posts AS s, websearch_to_tsquery('%[1]s', $1) AS q
+				WHERE s.search @@ q AND NOT s.isDeleted%[3]s
+			) AS r%[4]s
+			ORDER BY r.rank DESC, r.id DESC
+			LIMIT %[5]d) AS r
+		ORDER BY r.rank DESC, r.id DESC`, searchConfig, searchHeadlineOptions, filters, cursor, query.Limit)
+
+	rows, err := s.db.Query(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]entity.SearchResult, 0, query.Limit)
+	for rows.Next() {
+		result := entity.SearchResult{Type: entity.SearchTypePost}
+		err = rows.Scan(&result.ID, &result.Thread, &result.Forum, &result.Author, &result.Created, &result.Rank, &result.Snippet)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+func (s *SearchRepo) SearchThreads(query *entity.SearchQuery) ([]entity.SearchResult, error) {
+	filters, cursor, args := searchFilters(query, []interface{}{query.Text})
+
+	sqlQuery := fmt.Sprintf(`SELECT r.id, r.forum, r.author, r.created, r.rank, r.title,
+			ts_headline('%[1]s', r.msg, r.q, '%[2]s')
+		FROM (SELECT * FROM (
+				SELECT s.id, s.forum, s.author, s.created, s.title, s.msg, q, ts_rank(s.search, q) AS rank
+				FROM threads AS s, websearch_to_tsquery('%[1]s', $1) AS q
+				WHERE s.search @@ q%[3]s
+			) AS r%[4]s
+			ORDER BY r.rank DESC, r.id DESC
+			LIMIT %[5]d) AS r
+		ORDER BY r.rank DESC, r.id DESC`, searchConfig, searchHeadlineOptions, filters, cursor, query.Limit)
+
+	rows, err := s.db.Query(context.Background(), sqlQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := make([]entity.SearchResult, 0, query.Limit)
+	for rows.Next() {
+		result := entity.SearchResult{Type: entity.SearchTypeThread}
+		err = rows.Scan(&result.ID, &result.Forum, &result.Author, &result.Created, &result.Rank, &result.Title, &result.Snippet)
+		if err != nil {
+			return nil, err
+		}
+		result.Thread = result.ID
+		results = append(results, result)
+	}
+
+	return results, nil
+}
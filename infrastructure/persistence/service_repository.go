@@ -14,18 +14,52 @@ func NewServiceRepository(db *pgxpool.Pool) *ServiceRepo {
 	return &ServiceRepo{db: db}
 }
 
-const ClearDBQuery = `TRUNCATE TABLE Forum_user RESTART IDENTITY CASCADE;
+const ClearDBQuery = `TRUNCATE TABLE sessions RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE user_credentials RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE password_setup_tokens RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE notifications RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE global_roles RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE forum_moderators RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE bans RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE reports RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE report_resolutions RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE post_revisions RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE post_reaction RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE Forum_user RESTART IDENTITY CASCADE;
 			  TRUNCATE TABLE Thread_vote RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE Post_vote RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE thread_subscriptions RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE forum_subscriptions RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE read_markers RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE messages RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE conversation_members RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE conversations RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE user_blocks RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE user_ignores RESTART IDENTITY CASCADE;
+			  TRUNCATE TABLE forum_tags RESTART IDENTITY CASCADE;
 			  TRUNCATE TABLE Posts RESTART IDENTITY CASCADE;
 			  TRUNCATE TABLE Threads RESTART IDENTITY CASCADE;
 			  TRUNCATE TABLE Forums RESTART IDENTITY CASCADE;
 			  TRUNCATE TABLE Users RESTART IDENTITY CASCADE;`
-func (s *ServiceRepo) ClearAllDate() error {
-	_, err := s.db.Exec(context.Background(), ClearDBQuery)
+
+func (s *ServiceRepo) ClearAllDate(audit *entity.AuditEntry) error {
+	tx, err := s.db.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), ClearDBQuery)
+	if err != nil {
+		return err
+	}
+
+	err = addAuditEntry(tx, audit, nil)
 	if err != nil {
 		return err
 	}
-	return nil
+
+	return tx.Commit(context.Background())
 }
 
 const GetUserStatusQuery = `SELECT COUNT(*) AS user_count FROM Users;`
@@ -55,5 +89,3 @@ func (s *ServiceRepo) GetDBStatus() (*entity.Status, error) {
 
 	return status, nil
 }
-
-
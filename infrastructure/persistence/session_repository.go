@@ -0,0 +1,56 @@
+package persistence
+
+import (
+	"context"
+	"forum/domain/entity"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type SessionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSessionRepository(db *pgxpool.Pool) *SessionRepo {
+	return &SessionRepo{db: db}
+}
+
+const CreateSessionQuery = `INSERT INTO sessions (session_id, nickname, expires) VALUES ($1, $2, $3)`
+
+func (s *SessionRepo) CreateSession(session *entity.Session) error {
+	_, err := s.db.Exec(context.Background(), CreateSessionQuery, session.ID, session.Nickname, session.Expires)
+	return err
+}
+
+const GetUserBySessionQuery = `SELECT u.id, u.nickname, u.fullname, u.email, u.about, u.avatar, u.karma FROM sessions AS s
+		JOIN users AS u ON u.nickname = s.nickname
+		WHERE s.session_id = $1 AND s.expires > now()`
+
+func (s *SessionRepo) GetUserBySession(sessionID string) (*entity.User, error) {
+	user := &entity.User{}
+	err := s.db.QueryRow(context.Background(), GetUserBySessionQuery, sessionID).Scan(
+		&user.ID,
+		&user.Nickname,
+		&user.Fullname,
+		&user.Email,
+		&user.About,
+		&user.Avatar,
+		&user.Karma)
+
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.SessionNotFoundError
+		}
+		return nil, err
+	}
+
+	user.Avatar = avatarOrDefault(user.Avatar)
+	return user, nil
+}
+
+const DeleteSessionQuery = `DELETE FROM sessions WHERE session_id = $1`
+
+func (s *SessionRepo) DeleteSession(sessionID string) error {
+	_, err := s.db.Exec(context.Background(), DeleteSessionQuery, sessionID)
+	return err
+}
@@ -0,0 +1,201 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"forum/domain/entity"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+type SubscriptionRepo struct {
+	db *pgxpool.Pool
+}
+
+func NewSubscriptionRepository(db *pgxpool.Pool) *SubscriptionRepo {
+	return &SubscriptionRepo{db: db}
+}
+
+const SubscribeThreadQuery = `INSERT INTO thread_subscriptions (nickname, thread_id) VALUES ($1, $2)
+	ON CONFLICT DO NOTHING`
+const InitReadMarkerQuery = `INSERT INTO read_markers (nickname, thread_id, last_read_post)
+	SELECT $1, $2, COALESCE(MAX(id), 0) FROM posts WHERE thread = $2
+	ON CONFLICT DO NOTHING`
+
+// SubscribeThread starts the read marker at the newest post unless the user already has one for the thread
+func (s *SubscriptionRepo) SubscribeThread(nickname string, threadID int) error {
+	tx, err := s.db.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	_, err = tx.Exec(context.Background(), SubscribeThreadQuery, nickname, threadID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(context.Background(), InitReadMarkerQuery, nickname, threadID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(context.Background())
+}
+
+const UnsubscribeThreadQuery = `DELETE FROM thread_subscriptions WHERE nickname = $1 AND thread_id = $2`
+
+func (s *SubscriptionRepo) UnsubscribeThread(nickname string, threadID int) error {
+	_, err := s.db.Exec(context.Background(), UnsubscribeThreadQuery, nickname, threadID)
+	return err
+}
+
+const SubscribeForumQuery = `INSERT INTO forum_subscriptions (nickname, forum_slug, last_read_thread)
+	SELECT $1, $2, COALESCE(MAX(id), 0) FROM threads WHERE forum = $2
+	ON CONFLICT DO NOTHING`
+
+func (s *SubscriptionRepo) SubscribeForum(nickname string, forum string) error {
+	_, err := s.db.Exec(context.Background(), SubscribeForumQuery, nickname, forum)
+	return err
+}
+
+const UnsubscribeForumQuery = `DELETE FROM forum_subscriptions WHERE nickname = $1 AND forum_slug = $2`
+
+func (s *SubscriptionRepo) UnsubscribeForum(nickname string, forum string) error {
+	_, err := s.db.Exec(context.Background(), UnsubscribeForumQuery, nickname, forum)
+	return err
+}
+
+// GetFeed merges unopened threads newer than the forum markers with posts newer than the thread read markers,
+// leaving out what the user wrote or ignores; it pages on (created, type, item_id) since posts of one batch share created
+func (s *SubscriptionRepo) GetFeed(nickname string, query *entity.FeedQuery) ([]entity.FeedItem, error) {
+	feedQuery := `SELECT type, author, created, forum, thread_id, post_id, parent, title, msg, slug FROM (
+		SELECT 'thread' AS type, t.author, t.created, t.forum, t.id AS thread_id, 0 AS post_id, 0 AS parent,
+			t.title, t.msg, t.slug, t.id AS item_id
+		FROM forum_subscriptions AS fs
+		JOIN threads AS t ON t.forum = fs.forum_slug AND t.id > fs.last_read_thread
+		WHERE fs.nickname = $1 AND t.author <> $1
+			AND NOT EXISTS (SELECT 1 FROM read_markers WHERE nickname = $1 AND thread_id = t.id)
+			AND NOT EXISTS (SELECT 1 FROM user_ignores WHERE nickname = $1 AND ignored = t.author)
+		UNION ALL
+		SELECT 'post', p.author, p.created, p.forum, p.thread, p.id, COALESCE(p.parent, 0),
+			'', p.msg, NULL::CITEXT, p.id
+		FROM thread_subscriptions AS ts
+		LEFT JOIN read_markers AS m ON m.nickname = ts.nickname AND m.thread_id = ts.thread_id
+		JOIN posts AS p ON p.thread = ts.thread_id AND p.id > COALESCE(m.last_read_post, 0)
+		WHERE ts.nickname = $1 AND p.author <> $1 AND NOT p.isDeleted
+			AND NOT EXISTS (SELECT 1 FROM user_ignores WHERE nickname = $1 AND ignored = p.author)
+	) AS feed`
+	order := "ASC"
+	var compare string
+	if query.Desc == false {
+		compare = ">"
+	} else {
+		order = "DESC"
+		compare = "<"
+	}
+
+	args := []interface{}{nickname}
+	if query.AfterID != 0 {
+		args = append(args, query.AfterCreated, query.AfterType, query.AfterID)
+		feedQuery += fmt.Sprintf(" WHERE (created, type, item_id) %v ($2, $3, $4)", compare)
+	} else if query.Since != "" {
+		args = append(args, query.Since)
+		feedQuery += fmt.Sprintf(" WHERE created %v= $2", compare)
+	}
+
+	feedQuery += fmt.Sprintf(" ORDER BY created %v, type %v, item_id %v LIMIT %v", order, order, order, query.Limit)
+	rows, err := s.db.Query(context.Background(), feedQuery, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	feed := make([]entity.FeedItem, 0, query.Limit)
+	for rows.Next() {
+		item := entity.FeedItem{}
+		thread := &entity.Thread{}
+		post := &entity.Post{}
+		err = rows.Scan(&item.Type, &thread.Author, &thread.Created, &thread.Forum, &thread.ID, &post.ID, &post.Parent,
+			&thread.Title, &thread.Message, &thread.Slug)
+		if err != nil {
+			return nil, err
+		}
+
+		if item.Type == entity.FeedItemThread {
+			item.Thread = thread
+		} else {
+			post.Author = thread.Author
+			post.Created = thread.Created
+			post.Forum = thread.Forum
+			post.Thread = thread.ID
+			post.Message = thread.Message
+			item.Post = post
+		}
+		feed = append(feed, item)
+	}
+
+	return feed, nil
+}
+
+// MarkFeedThreadsSeenQuery gives served threads a read marker before their first post,
+// which takes them out of the feed and leaves their unread counts as they are
+const MarkFeedThreadsSeenQuery = `INSERT INTO read_markers (nickname, thread_id, last_read_post)
+	SELECT $1, unnest($2::INT[]), 0
+	ON CONFLICT DO NOTHING`
+
+func (s *SubscriptionRepo) MarkFeedThreadsSeen(nickname string, threadIDs []int) error {
+	_, err := s.db.Exec(context.Background(), MarkFeedThreadsSeenQuery, nickname, threadIDs)
+	return err
+}
+
+const GetSubscribedThreadsQuery = `SELECT t.author, t.created, t.forum, t.id, t.msg, t.slug, t.title, t.votes,
+		t.locked, t.pinned, t.archived,
+		(SELECT COUNT(*) FROM posts AS p WHERE p.thread = t.id AND p.id > COALESCE(m.last_read_post, 0))
+	FROM thread_subscriptions AS s
+	JOIN threads AS t ON t.id = s.thread_id
+	LEFT JOIN read_markers AS m ON m.nickname = s.nickname AND m.thread_id = s.thread_id
+	WHERE s.nickname = $1
+	ORDER BY s.created`
+
+func (s *SubscriptionRepo) GetSubscribedThreads(nickname string) ([]entity.Thread, error) {
+	rows, err := s.db.Query(context.Background(), GetSubscribedThreadsQuery, nickname)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	threads := make([]entity.Thread, 0)
+	for rows.Next() {
+		thread := entity.Thread{}
+		err = rows.Scan(&thread.Author, &thread.Created, &thread.Forum, &thread.ID, &thread.Message, &thread.Slug, &thread.Title, &thread.Votes,
+			&thread.Locked, &thread.Pinned, &thread.Archived, &thread.Unread)
+		if err != nil {
+			return nil, err
+		}
+		threads = append(threads, thread)
+	}
+
+	return threads, nil
+}
+
+const GetSubscribedForumsQuery = `SELECT forum_slug FROM forum_subscriptions WHERE nickname = $1 ORDER BY created`
+
+func (s *SubscriptionRepo) GetSubscribedForums(nickname string) ([]string, error) {
+	rows, err := s.db.Query(context.Background(), GetSubscribedForumsQuery, nickname)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	forums := make([]string, 0)
+	for rows.Next() {
+		var forum string
+		err = rows.Scan(&forum)
+		if err != nil {
+			return nil, err
+		}
+		forums = append(forums, forum)
+	}
+
+	return forums, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -18,14 +19,39 @@ type ThreadRepo struct {
 func NewThreadRepository(db *pgxpool.Pool) *ThreadRepo {
 	return &ThreadRepo{db: db}
 }
+
 const UpdatePostsCountQuery = `UPDATE forums SET post_count = post_count + $1 WHERE slug = $2;`
 const GetThreadFromPostsQuery = `SELECT thread FROM posts WHERE id = $1`
 const SelectSlugFromThread = `SELECT forum FROM threads WHERE id = $1`
+
+// GetThreadStateQuery holds the thread row until the posts are in, so MoveThread can not change the forum under them.
+// The lock is exclusive to serialize the inserts of a thread: its posts commit in id order, and the post stream,
+// which reads on from the last id it sent, can not skip a post that got a lower id but committed later
+const GetThreadStateQuery = `SELECT forum, locked, archived FROM threads WHERE id = $1 FOR UPDATE`
+
 func (t *ThreadRepo) CreatePosts(thread *entity.Thread, posts []entity.Post) error {
-	var  CreatePostsQuery = `INSERT INTO posts(author, created, forum, msg, parent, thread) VALUES `
+	var CreatePostsQuery = `INSERT INTO posts(author, created, forum, msg, parent, thread) VALUES `
+	tx, err := t.db.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	var locked, archived bool
+	err = tx.QueryRow(context.Background(), GetThreadStateQuery, thread.ID).Scan(&thread.Forum, &locked, &archived)
+	if err != nil {
+		return err
+	}
+	if archived {
+		return entity.ThreadArchivedError
+	}
+	if locked {
+		return entity.ThreadLockedError
+	}
+
 	if posts[0].Parent != 0 {
 		var parentThread int
-		err := t.db.QueryRow(context.Background(), GetThreadFromPostsQuery, posts[0].Parent).Scan(&parentThread)
+		err := tx.QueryRow(context.Background(), GetThreadFromPostsQuery, posts[0].Parent).Scan(&parentThread)
 
 		if err != nil {
 			return err
@@ -54,7 +80,7 @@ func (t *ThreadRepo) CreatePosts(thread *entity.Thread, posts []entity.Post) err
 
 	CreatePostsQuery = CreatePostsQuery[:len(CreatePostsQuery)-1]
 	CreatePostsQuery += ` RETURNING id`
-	rows, err := t.db.Query(context.Background(), CreatePostsQuery, postArray...)
+	rows, err := tx.Query(context.Background(), CreatePostsQuery, postArray...)
 	if err != nil {
 		return err
 	}
@@ -68,6 +94,10 @@ func (t *ThreadRepo) CreatePosts(thread *entity.Thread, posts []entity.Post) err
 
 		idx++
 	}
+	rows.Close()
+	if rows.Err() != nil {
+		return rows.Err()
+	}
 	//slug := ""
 	//err = t.db.QueryRow(context.Background(), SelectSlugFromThread, thread.ID).Scan(&slug)
 	//
@@ -80,14 +110,15 @@ func (t *ThreadRepo) CreatePosts(thread *entity.Thread, posts []entity.Post) err
 	//	return err
 	//}
 
-	return nil
+	return tx.Commit(context.Background())
 }
 
-const CreateThreadQuery = `INSERT INTO threads (author, created, forum, msg, title, slug)
-	VALUES ($1, $2, $3, $4, $5, $6) RETURNING id`
+const CreateThreadQuery = `INSERT INTO threads (author, created, forum, msg, title, slug, tags)
+	VALUES ($1, $2, $3, $4, $5, $6, COALESCE($7::TEXT[], '{}')) RETURNING id`
+
 func (t *ThreadRepo) CreateThread(thread *entity.Thread) error {
 	err := t.db.QueryRow(context.Background(), CreateThreadQuery,
-		thread.Author, thread.Created, thread.Forum, thread.Message, thread.Title, thread.Slug,
+		thread.Author, thread.Created, thread.Forum, thread.Message, thread.Title, thread.Slug, thread.Tags,
 	).Scan(&thread.ID)
 
 	if err != nil {
@@ -115,7 +146,7 @@ func (t *ThreadRepo) GetThreadPosts(slug string, limit int32, since string, orde
 		}
 	}
 
-	query := fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread FROM posts
+	query := fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread, isDeleted, reactions, votes FROM posts
 	WHERE thread = $1 %v
 	ORDER BY id %v`, sinceQuery, order)
 
@@ -132,10 +163,11 @@ func (t *ThreadRepo) GetThreadPosts(slug string, limit int32, since string, orde
 	posts := make([]entity.Post, 0, limit)
 	for rows.Next() {
 		post := entity.Post{}
-		err = rows.Scan(&post.Author, &post.Created, &post.Forum, &post.ID, &post.Message, &post.Parent, &post.Thread)
+		err = rows.Scan(&post.Author, &post.Created, &post.Forum, &post.ID, &post.Message, &post.Parent, &post.Thread, &post.IsDeleted, &post.Reactions, &post.Votes)
 		if err != nil {
 			return nil, err // TODO: error handling
 		}
+		tombstone(&post)
 		posts = append(posts, post)
 	}
 
@@ -162,19 +194,19 @@ func (t *ThreadRepo) GetThreadPostsTree(slug string, limit int32, since string,
 
 	if since == "" {
 		if desc {
-			query = fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread FROM posts
+			query = fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread, isDeleted, reactions, votes FROM posts
 				WHERE thread = %d ORDER BY path DESC, id  DESC LIMIT %d;`, threadID, limit)
 		} else {
-			query = fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread FROM posts
+			query = fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread, isDeleted, reactions, votes FROM posts
 				WHERE thread = %d ORDER BY path ASC, id  ASC LIMIT %d;`, threadID, limit)
 		}
 	} else {
 		if desc {
-			query = fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread FROM posts
+			query = fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread, isDeleted, reactions, votes FROM posts
 				WHERE thread = %d AND path < (SELECT path FROM posts WHERE id = %s)
 				ORDER BY path DESC, id  DESC LIMIT %d;`, threadID, since, limit)
 		} else {
-			query = fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread FROM posts
+			query = fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread, isDeleted, reactions, votes FROM posts
 				WHERE thread = %d AND path > (SELECT path FROM posts WHERE id = %s)
 				ORDER BY path ASC, id  ASC LIMIT %d;`, threadID, since, limit)
 		}
@@ -189,10 +221,11 @@ func (t *ThreadRepo) GetThreadPostsTree(slug string, limit int32, since string,
 	posts := make([]entity.Post, 0)
 	for rows.Next() {
 		post := entity.Post{}
-		err = rows.Scan(&post.Author, &post.Created, &post.Forum, &post.ID, &post.Message, &post.Parent, &post.Thread)
+		err = rows.Scan(&post.Author, &post.Created, &post.Forum, &post.ID, &post.Message, &post.Parent, &post.Thread, &post.IsDeleted, &post.Reactions, &post.Votes)
 		if err != nil {
 			return nil, err // TODO: error handling
 		}
+		tombstone(&post)
 		posts = append(posts, post)
 	}
 
@@ -218,22 +251,22 @@ func (t *ThreadRepo) GetThreadPostsParentTree(slug string, limit int32, since st
 	var query string
 	if since == "" {
 		if desc {
-			query = fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread FROM posts
+			query = fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread, isDeleted, reactions, votes FROM posts
 				WHERE path[1] IN (SELECT id FROM posts WHERE thread = %d AND parent = 0 ORDER BY id DESC LIMIT %d)
 				ORDER BY path[1] DESC, path, id;`, threadID, limit)
 		} else {
-			query = fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread FROM posts
+			query = fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread, isDeleted, reactions, votes FROM posts
 				WHERE path[1] IN (SELECT id FROM posts WHERE thread = %d AND parent = 0 ORDER BY id LIMIT %d)
 				ORDER BY path, id;`, threadID, limit)
 		}
 	} else {
 		if desc {
-			query = fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread FROM posts
+			query = fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread, isDeleted, reactions, votes FROM posts
 				WHERE path[1] IN (SELECT id FROM posts WHERE thread = %d AND parent = 0 AND path[1] <
 				(SELECT path[1] FROM posts WHERE id = %s) ORDER BY id DESC LIMIT %d) ORDER BY path[1] DESC, path, id;`,
 				threadID, since, limit)
 		} else {
-			query = fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread FROM posts
+			query = fmt.Sprintf(`SELECT author, created, forum, id, msg, parent, thread, isDeleted, reactions, votes FROM posts
 				WHERE path[1] IN (SELECT id FROM posts WHERE thread = %d AND parent = 0 AND path[1] >
 				(SELECT path[1] FROM posts WHERE id = %s) ORDER BY id ASC LIMIT %d) ORDER BY path, id;`,
 				threadID, since, limit)
@@ -250,10 +283,11 @@ func (t *ThreadRepo) GetThreadPostsParentTree(slug string, limit int32, since st
 	posts := make([]entity.Post, 0)
 	for rows.Next() {
 		post := entity.Post{}
-		err = rows.Scan(&post.Author, &post.Created, &post.Forum, &post.ID, &post.Message, &post.Parent, &post.Thread)
+		err = rows.Scan(&post.Author, &post.Created, &post.Forum, &post.ID, &post.Message, &post.Parent, &post.Thread, &post.IsDeleted, &post.Reactions, &post.Votes)
 		if err != nil {
 			return nil, err // TODO: error handling
 		}
+		tombstone(&post)
 		posts = append(posts, post)
 	}
 
@@ -261,6 +295,7 @@ func (t *ThreadRepo) GetThreadPostsParentTree(slug string, limit int32, since st
 }
 
 const CheckThreadBySlugQuery = `SELECT id FROM threads WHERE slug = $1`
+
 func (t *ThreadRepo) CheckThreadBySlug(slug string) (int, error) {
 	var id int
 	err := t.db.QueryRow(context.Background(), CheckThreadBySlugQuery, slug).Scan(&id)
@@ -273,6 +308,7 @@ func (t *ThreadRepo) CheckThreadBySlug(slug string) (int, error) {
 }
 
 const CheckThreadByIDQuery = `SELECT id FROM threads WHERE id = $1`
+
 func (t *ThreadRepo) CheckThreadByID(ID int) error {
 	err := t.db.QueryRow(context.Background(), CheckThreadByIDQuery, ID).Scan(&ID)
 
@@ -285,6 +321,7 @@ func (t *ThreadRepo) CheckThreadByID(ID int) error {
 
 const GetThreadForumAndIDBySlugQuery = `SELECT forum, id FROM threads WHERE slug = $1`
 const GetThreadForumAndIDByIDQuery = `SELECT forum FROM threads WHERE id = $1`
+
 func (t *ThreadRepo) GetThreadForumAndID(slugOrID string) (*entity.Thread, error) {
 	threadID, err := strconv.Atoi(slugOrID)
 	thread := &entity.Thread{ID: threadID}
@@ -301,8 +338,10 @@ func (t *ThreadRepo) GetThreadForumAndID(slugOrID string) (*entity.Thread, error
 	return thread, nil
 }
 
-func (t *ThreadRepo) GetThreadsByForumSlug(slug string, limit int32, since string, desc bool) ([]entity.Thread, error) {
-	var GetThreadsByForumSlugQuery = `SELECT author, created, forum, id, msg, slug, title, votes FROM threads WHERE forum = $1`
+// GetThreadsByForumSlug narrows the listing to threads carrying tag when it is set. Pinned threads lead the first
+// page and count against its limit, the pages after it, which start at since, only list unpinned threads
+func (t *ThreadRepo) GetThreadsByForumSlug(slug string, limit int32, since string, desc bool, tag string) ([]entity.Thread, error) {
+	var GetThreadsByForumSlugQuery = `SELECT author, created, forum, id, msg, slug, title, votes, locked, pinned, archived, tags, COALESCE(moved_to, 0) FROM threads WHERE forum = $1`
 	order := "ASC"
 	var compare string
 	if desc == false {
@@ -312,17 +351,80 @@ func (t *ThreadRepo) GetThreadsByForumSlug(slug string, limit int32, since strin
 		compare = "<"
 	}
 
+	args := []interface{}{slug}
+	if tag != "" {
+		args = append(args, tag)
+		GetThreadsByForumSlugQuery += fmt.Sprintf(" AND tags @> ARRAY[$%d::TEXT]", len(args))
+	}
+
+	threads := make([]entity.Thread, 0, limit)
+	var err error
+	if since == "" {
+		threads, err = t.scanThreads(threads, GetThreadsByForumSlugQuery+fmt.Sprintf(" AND pinned ORDER BY created %v LIMIT %v", order, limit), args...)
+		if err != nil {
+			return nil, err
+		}
+		if len(threads) == int(limit) {
+			return threads, nil
+		}
+	}
+
+	GetThreadsByForumSlugQuery += " AND NOT pinned"
 	if since != "" {
-		GetThreadsByForumSlugQuery += fmt.Sprintf(" AND created %v= $2", compare)
+		args = append(args, since)
+		GetThreadsByForumSlugQuery += fmt.Sprintf(" AND created %v= $%d", compare, len(args))
 	}
 
-	GetThreadsByForumSlugQuery += fmt.Sprintf(" ORDER BY created %v  LIMIT %v", order, limit)
+	GetThreadsByForumSlugQuery += fmt.Sprintf(" ORDER BY created %v  LIMIT %v", order, int(limit)-len(threads))
+	return t.scanThreads(threads, GetThreadsByForumSlugQuery, args...)
+}
+
+// scanThreads appends the threads selected by query, which lists the columns of GetThreadsByForumSlug
+func (t *ThreadRepo) scanThreads(threads []entity.Thread, query string, args ...interface{}) ([]entity.Thread, error) {
+	rows, err := t.db.Query(context.Background(), query, args...)
+
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		thread := entity.Thread{}
+		err = rows.Scan(&thread.Author, &thread.Created, &thread.Forum, &thread.ID, &thread.Message, &thread.Slug, &thread.Title, &thread.Votes,
+			&thread.Locked, &thread.Pinned, &thread.Archived, &thread.Tags, &thread.MovedTo)
+		if err != nil {
+			return nil, err // TODO: error handling
+		}
+		threads = append(threads, thread)
+	}
+
+	return threads, nil
+}
+
+// GetThreadsByTag lists tagged threads of all forums with the paging of GetThreadsByForumSlug
+func (t *ThreadRepo) GetThreadsByTag(tag string, limit int32, since string, desc bool) ([]entity.Thread, error) {
+	query := `SELECT author, created, forum, id, msg, slug, title, votes, locked, pinned, archived, tags, COALESCE(moved_to, 0) FROM threads
+		WHERE tags @> ARRAY[$1::TEXT]`
+	order := "ASC"
+	var compare string
+	if desc == false {
+		compare = ">"
+	} else {
+		order = "DESC"
+		compare = "<"
+	}
+
+	if since != "" {
+		query += fmt.Sprintf(" AND created %v= $2", compare)
+	}
+
+	query += fmt.Sprintf(" ORDER BY created %v, id %v LIMIT %v", order, order, limit)
 	var rows pgx.Rows
 	var err error
 	if since != "" {
-		rows, err = t.db.Query(context.Background(), GetThreadsByForumSlugQuery, slug, since)
+		rows, err = t.db.Query(context.Background(), query, tag, since)
 	} else {
-		rows, err = t.db.Query(context.Background(), GetThreadsByForumSlugQuery, slug)
+		rows, err = t.db.Query(context.Background(), query, tag)
 	}
 
 	if err != nil {
@@ -333,9 +435,10 @@ func (t *ThreadRepo) GetThreadsByForumSlug(slug string, limit int32, since strin
 	threads := make([]entity.Thread, 0, limit)
 	for rows.Next() {
 		thread := entity.Thread{}
-		err = rows.Scan(&thread.Author, &thread.Created, &thread.Forum, &thread.ID, &thread.Message, &thread.Slug, &thread.Title, &thread.Votes)
+		err = rows.Scan(&thread.Author, &thread.Created, &thread.Forum, &thread.ID, &thread.Message, &thread.Slug, &thread.Title, &thread.Votes,
+			&thread.Locked, &thread.Pinned, &thread.Archived, &thread.Tags, &thread.MovedTo)
 		if err != nil {
-			return nil, err // TODO: error handling
+			return nil, err
 		}
 		threads = append(threads, thread)
 	}
@@ -343,9 +446,59 @@ func (t *ThreadRepo) GetThreadsByForumSlug(slug string, limit int32, since strin
 	return threads, nil
 }
 
-const GetVoteQuery = `SELECT vote FROM thread_vote WHERE nickname = $1 AND thread_id = $2`
-const InsertVoteQuery = `INSERT INTO thread_vote (nickname, thread_id, vote) VALUES($1, $2, $3)`
-const UpdateVoteQuery = `UPDATE thread_vote SET vote = $1 WHERE nickname = $2 AND thread_id = $3`
+const GetForumTagsQuery = `SELECT tag, thread_count FROM forum_tags WHERE forum = $1 ORDER BY thread_count DESC, tag`
+
+func (t *ThreadRepo) GetForumTags(forum string) ([]entity.TagCount, error) {
+	rows, err := t.db.Query(context.Background(), GetForumTagsQuery, forum)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]entity.TagCount, 0)
+	for rows.Next() {
+		tag := entity.TagCount{}
+		err = rows.Scan(&tag.Tag, &tag.Threads)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+const SearchTagsQuery = `SELECT tag, SUM(thread_count)::INT FROM forum_tags WHERE tag LIKE $1
+	GROUP BY tag
+	ORDER BY SUM(thread_count) DESC, tag
+	LIMIT $2`
+
+// SearchTags completes prefix to the most used tags, the prefix must already have its LIKE wildcards escaped
+func (t *ThreadRepo) SearchTags(prefix string, limit int32) ([]entity.TagCount, error) {
+	rows, err := t.db.Query(context.Background(), SearchTagsQuery, prefix+"%", limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	tags := make([]entity.TagCount, 0, limit)
+	for rows.Next() {
+		tag := entity.TagCount{}
+		err = rows.Scan(&tag.Tag, &tag.Threads)
+		if err != nil {
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// a concurrent first vote of the same user turns into the update instead of failing on the unique constraint
+const VoteThreadQuery = `INSERT INTO thread_vote (nickname, thread_id, vote) VALUES($1, $2, $3)
+	ON CONFLICT (nickname, thread_id) DO UPDATE SET vote = EXCLUDED.vote WHERE thread_vote.vote <> EXCLUDED.vote`
+
+// VoteForThread stores the voice of vote.Nickname, the thread is read again for the score kept by the thread_vote triggers
 func (t *ThreadRepo) VoteForThread(vote *entity.Vote) (*entity.Thread, error) {
 	thread := &entity.Thread{}
 	var err error
@@ -359,41 +512,81 @@ func (t *ThreadRepo) VoteForThread(vote *entity.Vote) (*entity.Thread, error) {
 	if err != nil {
 		return nil, err
 	}
-	var voteValue int
-	err = t.db.QueryRow(context.Background(), GetVoteQuery,	vote.Nickname, thread.ID).Scan(&voteValue)
+	if thread.Archived {
+		return nil, entity.ThreadArchivedError
+	}
 
-	if err != nil && err != pgx.ErrNoRows {
+	_, err = t.db.Exec(context.Background(), VoteThreadQuery, vote.Nickname, thread.ID, vote.Voice)
+	if err != nil {
 		return nil, err
 	}
 
-	if err == pgx.ErrNoRows {
-		_, err = t.db.Exec(context.Background(), InsertVoteQuery, vote.Nickname, thread.ID, vote.Voice)
+	return t.GetThreadByID(thread.ID)
+}
 
-		if err != nil {
-			return nil, err
-		}
+const DeleteVoteQuery = `DELETE FROM thread_vote WHERE nickname = $1 AND thread_id = $2`
+
+func (t *ThreadRepo) RetractThreadVote(nickname string, threadID int) error {
+	_, err := t.db.Exec(context.Background(), DeleteVoteQuery, nickname, threadID)
+	return err
+}
+
+// GetThreadVotesQuery pages by nickname, since is the nickname of the last voter of the previous page
+const GetThreadVotesQuery = `SELECT nickname, thread_id, vote FROM thread_vote
+	WHERE thread_id = $1 AND ($2::TEXT = '' OR nickname %[1]v $2::CITEXT)
+	ORDER BY nickname %[2]v
+	LIMIT NULLIF($3, 0)`
 
-		thread.Votes += vote.Voice
-		return thread, nil
+func (t *ThreadRepo) GetThreadVotes(threadID int, limit int32, since string, order string, compare string) ([]entity.ThreadVote, error) {
+	query := fmt.Sprintf(GetThreadVotesQuery, compare, order)
+	rows, err := t.db.Query(context.Background(), query, threadID, since, limit)
+	if err != nil {
+		return nil, err
 	}
+	defer rows.Close()
 
-	if voteValue == vote.Voice {
-		return thread, nil
+	votes := make([]entity.ThreadVote, 0, limit)
+	for rows.Next() {
+		vote := entity.ThreadVote{}
+		err = rows.Scan(&vote.Nickname, &vote.Thread, &vote.Voice)
+		if err != nil {
+			return nil, err
+		}
+		votes = append(votes, vote)
 	}
+	return votes, nil
+}
 
-	thread.Votes = thread.Votes - voteValue + vote.Voice
+// GetUserThreadVotesQuery pages by thread id, since is the id of the last thread of the previous page
+const GetUserThreadVotesQuery = `SELECT v.nickname, t.id, COALESCE(t.slug, ''), t.title, v.vote FROM thread_vote AS v
+	JOIN threads AS t ON t.id = v.thread_id
+	WHERE v.nickname = $1 AND ($2 = 0 OR t.id %[1]v $2)
+	ORDER BY t.id %[2]v
+	LIMIT NULLIF($3, 0)`
 
-	_, err = t.db.Exec(context.Background(), UpdateVoteQuery, vote.Voice, vote.Nickname, thread.ID)
+func (t *ThreadRepo) GetUserThreadVotes(nickname string, limit int32, since int, order string, compare string) ([]entity.ThreadVote, error) {
+	query := fmt.Sprintf(GetUserThreadVotesQuery, compare, order)
+	rows, err := t.db.Query(context.Background(), query, nickname, since, limit)
 	if err != nil {
 		return nil, err
 	}
-	return thread, nil
+	defer rows.Close()
+
+	votes := make([]entity.ThreadVote, 0, limit)
+	for rows.Next() {
+		vote := entity.ThreadVote{}
+		err = rows.Scan(&vote.Nickname, &vote.Thread, &vote.Slug, &vote.Title, &vote.Voice)
+		if err != nil {
+			return nil, err
+		}
+		votes = append(votes, vote)
+	}
+	return votes, nil
 }
 
-const GetThreadBySlugQuery = `SELECT author, created, forum, id, msg, slug, title, votes FROM threads WHERE slug = $1`
-func (t *ThreadRepo) GetThreadBySlug(slug string) (*entity.Thread, error) {
-	thread := &entity.Thread{}
-	err := t.db.QueryRow(context.Background(), GetThreadBySlugQuery, slug).Scan(
+// scanThread reads the columns of GetThreadBySlugQuery
+func scanThread(row pgx.Row, thread *entity.Thread) error {
+	return row.Scan(
 		&thread.Author,
 		&thread.Created,
 		&thread.Forum,
@@ -401,37 +594,50 @@ func (t *ThreadRepo) GetThreadBySlug(slug string) (*entity.Thread, error) {
 		&thread.Message,
 		&thread.Slug,
 		&thread.Title,
-		&thread.Votes)
+		&thread.Votes,
+		&thread.Locked,
+		&thread.Pinned,
+		&thread.Archived,
+		&thread.Tags,
+		&thread.MovedTo)
+}
 
+const GetThreadBySlugQuery = `SELECT author, created, forum, id, msg, slug, title, votes, locked, pinned, archived, tags, COALESCE(moved_to, 0) FROM threads WHERE slug = $1`
+
+func (t *ThreadRepo) GetThreadBySlug(slug string) (*entity.Thread, error) {
+	thread := &entity.Thread{}
+	err := scanThread(t.db.QueryRow(context.Background(), GetThreadBySlugQuery, slug), thread)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ThreadNotFoundError
+	}
 	if err != nil {
 		return nil, err
 	}
 	return thread, nil
 }
 
-const GetThreadByIDQuery = `SELECT author, created, forum, id, msg, slug, title, votes FROM threads WHERE id = $1`
-	func (t *ThreadRepo) GetThreadByID(ID int) (*entity.Thread, error) {
-	thread := &entity.Thread{}
-	err := t.db.QueryRow(context.Background(), GetThreadByIDQuery, ID).Scan(
-		&thread.Author,
-		&thread.Created,
-		&thread.Forum,
-		&thread.ID,
-		&thread.Message,
-		&thread.Slug,
-		&thread.Title,
-		&thread.Votes)
+const GetThreadByIDQuery = `SELECT author, created, forum, id, msg, slug, title, votes, locked, pinned, archived, tags, COALESCE(moved_to, 0) FROM threads WHERE id = $1`
 
+func (t *ThreadRepo) GetThreadByID(ID int) (*entity.Thread, error) {
+	thread := &entity.Thread{}
+	err := scanThread(t.db.QueryRow(context.Background(), GetThreadByIDQuery, ID), thread)
+	if err == pgx.ErrNoRows {
+		return nil, entity.ThreadNotFoundError
+	}
 	if err != nil {
 		return nil, err
 	}
 	return thread, nil
 }
 
-const UpdateThreadQuery = `UPDATE threads SET title = $1, msg = $2
-		WHERE slug = $3 OR id = $4
-		RETURNING author, created, forum, id, msg, slug, title`
-func (t *ThreadRepo) UpdateThread(thread *entity.Thread) error {
+const UpdateThreadQuery = `UPDATE threads SET title = $1, msg = $2, tags = COALESCE($5::TEXT[], tags)
+		WHERE (slug = $3 OR id = $4) AND NOT archived
+		RETURNING author, created, forum, id, msg, slug, title, tags`
+const IsThreadArchivedQuery = `SELECT EXISTS(SELECT 1 FROM threads WHERE (slug = $1 OR id = $2) AND archived)`
+
+// UpdateThread leaves an archived thread alone with ThreadArchivedError, the check is part of the update
+// so a thread archived meanwhile is not edited
+func (t *ThreadRepo) UpdateThread(thread *entity.Thread, audit *entity.AuditEntry) error {
 	if thread.Title == "" || thread.Message == "" {
 		oldThread := &entity.Thread{}
 		var err error
@@ -454,13 +660,348 @@ func (t *ThreadRepo) UpdateThread(thread *entity.Thread) error {
 		}
 	}
 
-	err := t.db.QueryRow(context.Background(),UpdateThreadQuery,
-		thread.Title, thread.Message, thread.Slug, thread.ID,
-	).Scan(&thread.Author, &thread.Created, &thread.Forum, &thread.ID, &thread.Message, &thread.Slug, &thread.Title)
+	tx, err := t.db.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	err = tx.QueryRow(context.Background(), UpdateThreadQuery,
+		thread.Title, thread.Message, thread.Slug, thread.ID, thread.Tags,
+	).Scan(&thread.Author, &thread.Created, &thread.Forum, &thread.ID, &thread.Message, &thread.Slug, &thread.Title, &thread.Tags)
+	if err == pgx.ErrNoRows {
+		var archived bool
+		err = tx.QueryRow(context.Background(), IsThreadArchivedQuery, thread.Slug, thread.ID).Scan(&archived)
+		if err != nil {
+			return err
+		}
+		if archived {
+			return entity.ThreadArchivedError
+		}
+		return entity.ThreadNotFoundError
+	}
+	if err != nil {
+		return err
+	}
+
+	err = addAuditEntry(tx, audit, thread)
+	if err != nil {
+		return err
+	}
 
+	return tx.Commit(context.Background())
+}
+
+const GetLastPostIDQuery = `SELECT COALESCE(MAX(id), 0) FROM posts WHERE thread = $1`
+
+func (t *ThreadRepo) GetLastPostID(threadID int) (int, error) {
+	var lastID int
+	err := t.db.QueryRow(context.Background(), GetLastPostIDQuery, threadID).Scan(&lastID)
+	if err != nil {
+		return 0, err
+	}
+
+	return lastID, nil
+}
+
+const LockThreadQuery = `SELECT forum FROM threads WHERE id = $1 FOR UPDATE`
+const DeleteThreadReportsQuery = `DELETE FROM reports WHERE (target_type = 'thread' AND target_id = $1)
+	OR (target_type = 'post' AND target_id IN (SELECT id FROM posts WHERE thread = $1))`
+const DeleteThreadRevisionsQuery = `DELETE FROM post_revisions WHERE post IN (SELECT id FROM posts WHERE thread = $1)`
+const DeleteThreadReactionsQuery = `DELETE FROM post_reaction WHERE post IN (SELECT id FROM posts WHERE thread = $1)`
+const DeleteThreadPostVotesQuery = `DELETE FROM post_vote WHERE post_id IN (SELECT id FROM posts WHERE thread = $1)`
+const DeleteThreadNotificationsQuery = `DELETE FROM notifications WHERE thread = $1`
+const DeleteThreadPostsQuery = `DELETE FROM posts WHERE thread = $1`
+const DeleteThreadVotesQuery = `DELETE FROM thread_vote WHERE thread_id = $1`
+const DeleteThreadSubscriptionsQuery = `DELETE FROM thread_subscriptions WHERE thread_id = $1`
+const DeleteThreadReadMarkersQuery = `DELETE FROM read_markers WHERE thread_id = $1`
+const DeleteThreadQuery = `DELETE FROM threads WHERE id = $1`
+
+// redirect stubs are not counted anywhere, so removing them with their target leaves the counters of their forums as they are
+const DeleteRedirectStubVotesQuery = `DELETE FROM thread_vote WHERE thread_id IN (SELECT id FROM threads WHERE moved_to = ANY($1))`
+const DeleteRedirectStubSubscriptionsQuery = `DELETE FROM thread_subscriptions WHERE thread_id IN (SELECT id FROM threads WHERE moved_to = ANY($1))`
+const DeleteRedirectStubReadMarkersQuery = `DELETE FROM read_markers WHERE thread_id IN (SELECT id FROM threads WHERE moved_to = ANY($1))`
+const DeleteRedirectStubsQuery = `DELETE FROM threads WHERE moved_to = ANY($1)`
+
+func deleteRedirectStubs(tx pgx.Tx, threadIDs []int) error {
+	for _, query := range []string{
+		DeleteRedirectStubVotesQuery,
+		DeleteRedirectStubSubscriptionsQuery,
+		DeleteRedirectStubReadMarkersQuery,
+		DeleteRedirectStubsQuery,
+	} {
+		_, err := tx.Exec(context.Background(), query, threadIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RecomputeForumUsersQuery and RecomputeForumCountersQuery leave redirect stubs out, like the insert triggers do
+const RecomputeForumUsersQuery = `DELETE FROM forum_user AS fu WHERE fu.forum_slug = $1
+	AND NOT EXISTS (SELECT 1 FROM threads WHERE forum = fu.forum_slug AND author = fu.nickname AND moved_to IS NULL)
+	AND NOT EXISTS (SELECT 1 FROM posts WHERE forum = fu.forum_slug AND author = fu.nickname)`
+const RecomputeForumCountersQuery = `UPDATE forums SET
+	thread_count = (SELECT COUNT(*) FROM threads WHERE forum = $1 AND moved_to IS NULL),
+	post_count = (SELECT COUNT(*) FROM posts WHERE forum = $1 AND NOT isDeleted)
+	WHERE slug = $1`
+
+// DeleteThread removes the thread with its posts, votes, notifications and redirect stubs in one transaction
+// and recomputes the counters of its forum; the thread row is locked before the forum row, as in CreatePosts
+func (t *ThreadRepo) DeleteThread(thread *entity.Thread, audit *entity.AuditEntry) error {
+	tx, err := t.db.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	var forum string
+	err = tx.QueryRow(context.Background(), LockThreadQuery, thread.ID).Scan(&forum)
+	if err == pgx.ErrNoRows {
+		return entity.ThreadNotFoundError
+	}
+	if err != nil {
+		return err
+	}
+	err = tx.QueryRow(context.Background(), LockForumQuery, forum).Scan(&forum)
 	if err != nil {
 		return err
 	}
 
+	err = deleteRedirectStubs(tx, []int{thread.ID})
+	if err != nil {
+		return err
+	}
+
+	for _, query := range []string{
+		DeleteThreadReportsQuery,
+		DeleteThreadRevisionsQuery,
+		DeleteThreadReactionsQuery,
+		DeleteThreadPostVotesQuery,
+		DeleteThreadNotificationsQuery,
+		DeleteThreadPostsQuery,
+		DeleteThreadVotesQuery,
+		DeleteThreadSubscriptionsQuery,
+		DeleteThreadReadMarkersQuery,
+	} {
+		_, err = tx.Exec(context.Background(), query, thread.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	tag, err := tx.Exec(context.Background(), DeleteThreadQuery, thread.ID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.ThreadNotFoundError
+	}
+
+	_, err = tx.Exec(context.Background(), RecomputeForumUsersQuery, forum)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Exec(context.Background(), RecomputeForumCountersQuery, forum)
+	if err != nil {
+		return err
+	}
+
+	err = addAuditEntry(tx, audit, nil)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(context.Background())
+}
+
+const UpdateThreadStateQuery = `UPDATE threads SET
+		locked = COALESCE($1, locked), pinned = COALESCE($2, pinned), archived = COALESCE($3, archived)
+		WHERE id = $4
+		RETURNING author, created, forum, id, msg, slug, title, votes, locked, pinned, archived, tags, COALESCE(moved_to, 0)`
+
+func (t *ThreadRepo) UpdateThreadState(threadID int, state *entity.ThreadStateInput, audit *entity.AuditEntry) error {
+	tx, err := t.db.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	thread := &entity.Thread{}
+	err = scanThread(tx.QueryRow(context.Background(), UpdateThreadStateQuery, state.Locked, state.Pinned, state.Archived, threadID), thread)
+	if err == pgx.ErrNoRows {
+		return entity.ThreadNotFoundError
+	}
+	if err != nil {
+		return err
+	}
+
+	err = addAuditEntry(tx, audit, thread)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(context.Background())
+}
+
+const MarkThreadReadQuery = `INSERT INTO read_markers (nickname, thread_id, last_read_post)
+	SELECT $1, $2, $4 WHERE NOT EXISTS (SELECT 1 FROM posts WHERE thread = $2 AND id < $3
+		AND id > COALESCE((SELECT last_read_post FROM read_markers WHERE nickname = $1 AND thread_id = $2), 0))
+	ON CONFLICT (nickname, thread_id) DO UPDATE SET last_read_post = GREATEST(read_markers.last_read_post, EXCLUDED.last_read_post)`
+
+// MarkThreadRead moves the marker to lastID for a page of consecutive posts from firstID to lastID, but only
+// when no post between the marker and firstID was skipped; paging back through old posts keeps newer ones read
+func (t *ThreadRepo) MarkThreadRead(nickname string, threadID int, firstID int, lastID int) error {
+	_, err := t.db.Exec(context.Background(), MarkThreadReadQuery, nickname, threadID, firstID, lastID)
+	return err
+}
+
+const SetReadMarkerQuery = `INSERT INTO read_markers (nickname, thread_id, last_read_post)
+	SELECT $1, $2, CASE WHEN $3 = 0 THEN (SELECT COALESCE(MAX(id), 0) FROM posts WHERE thread = $2) ELSE $3 END
+	WHERE $3 = 0 OR EXISTS (SELECT 1 FROM posts WHERE id = $3 AND thread = $2)
+	ON CONFLICT (nickname, thread_id) DO UPDATE SET last_read_post = EXCLUDED.last_read_post`
+
+// SetReadMarker refuses a post of another thread with PostNotFoundError
+func (t *ThreadRepo) SetReadMarker(nickname string, threadID int, postID int) error {
+	tag, err := t.db.Exec(context.Background(), SetReadMarkerQuery, nickname, threadID, postID)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.PostNotFoundError
+	}
 	return nil
-}
\ No newline at end of file
+}
+
+// GetUnreadCountsQuery counts on index_posts_thread_id, a thread without a marker is unread from its first post;
+// deleted posts are not counted
+const GetUnreadCountsQuery = `SELECT t.id,
+		(SELECT COUNT(*) FROM posts AS p WHERE p.thread = t.id AND p.id > COALESCE(m.last_read_post, 0) AND NOT p.isDeleted)
+	FROM unnest($2::INT[]) AS t(id)
+	LEFT JOIN read_markers AS m ON m.nickname = $1 AND m.thread_id = t.id`
+
+func (t *ThreadRepo) GetUnreadCounts(nickname string, threadIDs []int) (map[int]int, error) {
+	rows, err := t.db.Query(context.Background(), GetUnreadCountsQuery, nickname, threadIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	unread := make(map[int]int, len(threadIDs))
+	for rows.Next() {
+		var threadID, count int
+		err = rows.Scan(&threadID, &count)
+		if err != nil {
+			return nil, err
+		}
+		unread[threadID] = count
+	}
+
+	return unread, nil
+}
+
+const MoveThreadQuery = `UPDATE threads SET forum = $2 WHERE id = $1`
+const MoveThreadPostsQuery = `UPDATE posts SET forum = $2 WHERE thread = $1`
+const MoveThreadNotificationsQuery = `UPDATE notifications SET forum = $2 WHERE thread = $1`
+const MoveThreadReportsQuery = `UPDATE reports SET forum = $2 WHERE (target_type = 'thread' AND target_id = $1)
+	OR (target_type = 'post' AND target_id IN (SELECT id FROM posts WHERE thread = $1))`
+const MoveThreadForumUsersQuery = `INSERT INTO forum_user (nickname, forum_slug)
+	SELECT author, $2 FROM threads WHERE id = $1
+	UNION
+	SELECT author, $2 FROM posts WHERE thread = $1
+	ON CONFLICT DO NOTHING`
+
+// a thread moved back into a forum where it left a redirect stub takes the place of the stub
+const MoveThreadStubVotesQuery = `DELETE FROM thread_vote
+	WHERE thread_id IN (SELECT id FROM threads WHERE moved_to = $1 AND forum = $2)`
+const MoveThreadStubSubscriptionsQuery = `DELETE FROM thread_subscriptions
+	WHERE thread_id IN (SELECT id FROM threads WHERE moved_to = $1 AND forum = $2)`
+const MoveThreadStubReadMarkersQuery = `DELETE FROM read_markers
+	WHERE thread_id IN (SELECT id FROM threads WHERE moved_to = $1 AND forum = $2)`
+const MoveThreadStubQuery = `DELETE FROM threads WHERE moved_to = $1 AND forum = $2`
+const CreateRedirectStubQuery = `INSERT INTO threads (author, created, forum, msg, title, locked, moved_to)
+	VALUES ($1, $2, $3, $4, $5, TRUE, $6) RETURNING id`
+
+// MoveThread rewrites the forum of the thread and everything hanging off it in one transaction, then recomputes
+// the counters and users of both forums; thread must carry ID and its current Forum, stub is created in the old forum when set
+func (t *ThreadRepo) MoveThread(thread *entity.Thread, forum string, stub *entity.Thread, audit *entity.AuditEntry) error {
+	tx, err := t.db.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	// the thread row goes first, so posts being created wait and then pick up the new forum
+	var current string
+	err = tx.QueryRow(context.Background(), LockThreadQuery, thread.ID).Scan(&current)
+	if err == pgx.ErrNoRows {
+		return entity.ThreadNotFoundError
+	}
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(current, thread.Forum) {
+		return entity.ThreadMovedError
+	}
+
+	// both forums are locked in slug order, so two opposite moves can not deadlock
+	locks := []string{thread.Forum, forum}
+	if strings.ToLower(locks[0]) > strings.ToLower(locks[1]) {
+		locks[0], locks[1] = locks[1], locks[0]
+	}
+	var locked string
+	for _, slug := range locks {
+		err = tx.QueryRow(context.Background(), LockForumQuery, slug).Scan(&locked)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, query := range []string{
+		MoveThreadStubVotesQuery,
+		MoveThreadStubSubscriptionsQuery,
+		MoveThreadStubReadMarkersQuery,
+		MoveThreadStubQuery,
+		MoveThreadQuery,
+		MoveThreadPostsQuery,
+		MoveThreadNotificationsQuery,
+		MoveThreadReportsQuery,
+		MoveThreadForumUsersQuery,
+	} {
+		_, err = tx.Exec(context.Background(), query, thread.ID, forum)
+		if err != nil {
+			return err
+		}
+	}
+
+	if stub != nil {
+		err = tx.QueryRow(context.Background(), CreateRedirectStubQuery,
+			stub.Author, stub.Created, stub.Forum, stub.Message, stub.Title, stub.MovedTo,
+		).Scan(&stub.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.Exec(context.Background(), RecomputeForumUsersQuery, thread.Forum)
+	if err != nil {
+		return err
+	}
+	for _, slug := range []string{thread.Forum, forum} {
+		_, err = tx.Exec(context.Background(), RecomputeForumCountersQuery, slug)
+		if err != nil {
+			return err
+		}
+	}
+
+	movedThread := *thread
+	movedThread.Forum = forum
+	err = addAuditEntry(tx, audit, &movedThread)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(context.Background())
+}
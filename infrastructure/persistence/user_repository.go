@@ -5,6 +5,7 @@ import (
 	"forum/domain/entity"
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"time"
 )
 
 type UserRepo struct {
@@ -23,7 +24,16 @@ func emptyIfNil(input *string) *string {
 	return input
 }
 
+// avatarOrDefault replaces missing avatar with the default one, noop otherwise
+func avatarOrDefault(avatar string) string {
+	if avatar == "" {
+		return entity.AvatarDefaultPath
+	}
+	return avatar
+}
+
 const CreateUserQuery = `INSERT INTO users (nickname, fullname, email, about) VALUES ($1, $2, $3, $4)`
+
 func (us *UserRepo) CreateUser(user *entity.User) error {
 	_, err := us.db.Exec(context.Background(),
 		CreateUserQuery,
@@ -34,10 +44,12 @@ func (us *UserRepo) CreateUser(user *entity.User) error {
 		return err
 	}
 
+	user.Avatar = entity.AvatarDefaultPath
 	return nil
 }
 
 const CheckUserExistQuery = `SELECT nickname FROM users WHERE nickname = $1`
+
 func (us *UserRepo) CheckIfUserExists(nickname string) (string, error) {
 	err := us.db.QueryRow(context.Background(), CheckUserExistQuery, nickname).Scan(&nickname)
 	if err != nil {
@@ -46,7 +58,8 @@ func (us *UserRepo) CheckIfUserExists(nickname string) (string, error) {
 	return nickname, nil
 }
 
-const GetUserByNickname = `SELECT id, nickname, fullname, email, about FROM users WHERE nickname = $1`
+const GetUserByNickname = `SELECT id, nickname, fullname, email, about, avatar, karma FROM users WHERE nickname = $1`
+
 func (us *UserRepo) GetUserByNickname(nickname string) (*entity.User, error) {
 	user := &entity.User{}
 	err := us.db.QueryRow(context.Background(), GetUserByNickname, nickname).Scan(
@@ -54,7 +67,9 @@ func (us *UserRepo) GetUserByNickname(nickname string) (*entity.User, error) {
 		&user.Nickname,
 		&user.Fullname,
 		&user.Email,
-		&user.About)
+		&user.About,
+		&user.Avatar,
+		&user.Karma)
 
 	if err != nil {
 		if err == pgx.ErrNoRows {
@@ -63,10 +78,12 @@ func (us *UserRepo) GetUserByNickname(nickname string) (*entity.User, error) {
 		return nil, err
 	}
 
+	user.Avatar = avatarOrDefault(user.Avatar)
 	return user, nil
 }
 
 const UpdateUserQuery = `UPDATE users SET fullname = $1, email = $2, about = $3 WHERE id = $4`
+
 func (us *UserRepo) UpdateUser(newUser *entity.User) (*entity.User, error) {
 	_, err := us.db.Exec(context.Background(), UpdateUserQuery, newUser.Fullname, newUser.Email, newUser.About, newUser.ID)
 	if err != nil {
@@ -77,6 +94,7 @@ func (us *UserRepo) UpdateUser(newUser *entity.User) (*entity.User, error) {
 }
 
 const GetUserNicknameWithEmailQuery = `SELECT nickname FROM users WHERE email = $1`
+
 func (us *UserRepo) GetUserNicknameWithEmail(email string) (string, error) {
 	var nickname string
 	err := us.db.QueryRow(context.Background(), GetUserNicknameWithEmailQuery, email).Scan(&nickname)
@@ -88,11 +106,11 @@ func (us *UserRepo) GetUserNicknameWithEmail(email string) (string, error) {
 	return nickname, nil
 }
 
-const GetUserWithNicknameAndEmailQuery = `SELECT nickname, fullname, email, about FROM users
+const GetUserWithNicknameAndEmailQuery = `SELECT nickname, fullname, email, about, avatar, karma FROM users
 		WHERE nickname = $1 OR email = $2`
+
 func (us *UserRepo) GetUsersWithNicknameAndEmail(nickname, email string) ([]entity.User, error) {
-	rows, err := us.db.Query(context.Background(), GetUserWithNicknameAndEmailQuery, nickname, email,
-	)
+	rows, err := us.db.Query(context.Background(), GetUserWithNicknameAndEmailQuery, nickname, email)
 	if err != nil {
 		return nil, err
 	}
@@ -101,12 +119,92 @@ func (us *UserRepo) GetUsersWithNicknameAndEmail(nickname, email string) ([]enti
 	users := make([]entity.User, 0)
 	for rows.Next() {
 		user := entity.User{}
-		err = rows.Scan(&user.Nickname, &user.Fullname, &user.Email, &user.About)
+		err = rows.Scan(&user.Nickname, &user.Fullname, &user.Email, &user.About, &user.Avatar, &user.Karma)
 		if err != nil {
 			return nil, err // TODO: error handling
 		}
+		user.Avatar = avatarOrDefault(user.Avatar)
 		users = append(users, user)
 	}
 
 	return users, nil
 }
+
+const GetPasswordHashQuery = `SELECT password_hash FROM user_credentials WHERE nickname = $1`
+
+func (us *UserRepo) GetPasswordHash(nickname string) ([]byte, error) {
+	var hash string
+	err := us.db.QueryRow(context.Background(), GetPasswordHashQuery, nickname).Scan(&hash)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, entity.PasswordNotSetError
+		}
+		return nil, err
+	}
+
+	return []byte(hash), nil
+}
+
+const SetPasswordHashQuery = `INSERT INTO user_credentials (nickname, password_hash) VALUES ($1, $2)
+		ON CONFLICT (nickname) DO UPDATE SET password_hash = EXCLUDED.password_hash, updated = now()`
+
+func (us *UserRepo) SetPasswordHash(nickname string, hash []byte) error {
+	_, err := us.db.Exec(context.Background(), SetPasswordHashQuery, nickname, string(hash))
+	return err
+}
+
+const SetPasswordSetupTokenQuery = `INSERT INTO password_setup_tokens (nickname, token_hash, expires) VALUES ($1, $2, $3)
+		ON CONFLICT (nickname) DO UPDATE SET token_hash = EXCLUDED.token_hash, expires = EXCLUDED.expires`
+
+func (us *UserRepo) SetPasswordSetupToken(nickname string, tokenHash string, expires time.Time) error {
+	_, err := us.db.Exec(context.Background(), SetPasswordSetupTokenQuery, nickname, tokenHash, expires)
+	return err
+}
+
+const ConsumePasswordSetupTokenQuery = `DELETE FROM password_setup_tokens
+		WHERE nickname = $1 AND token_hash = $2 AND expires > now()`
+const InsertFirstPasswordHashQuery = `INSERT INTO user_credentials (nickname, password_hash) VALUES ($1, $2)
+		ON CONFLICT (nickname) DO NOTHING`
+
+// SetFirstPasswordHash spends the setup token and stores the hash in one transaction,
+// an account that already has a password keeps it
+func (us *UserRepo) SetFirstPasswordHash(nickname string, tokenHash string, hash []byte) error {
+	tx, err := us.db.Begin(context.Background())
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(context.Background())
+
+	tag, err := tx.Exec(context.Background(), ConsumePasswordSetupTokenQuery, nickname, tokenHash)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.InvalidSetupTokenError
+	}
+
+	tag, err = tx.Exec(context.Background(), InsertFirstPasswordHashQuery, nickname, string(hash))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return entity.PasswordAlreadySetError
+	}
+
+	return tx.Commit(context.Background())
+}
+
+const UpdateAvatarQuery = `UPDATE users SET avatar = $1 WHERE nickname = $2`
+
+func (us *UserRepo) UpdateAvatar(nickname string, avatar string) error {
+	tag, err := us.db.Exec(context.Background(), UpdateAvatarQuery, avatar, nickname)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return entity.UserDoesntExistsError
+	}
+
+	return nil
+}
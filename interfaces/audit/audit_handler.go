@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"errors"
+	"forum/application"
+	"forum/domain/entity"
+	"forum/interfaces/common"
+	json "github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+	"net/http"
+	"strconv"
+)
+
+type AuditInfo struct {
+	auditApp application.AuditAppInterface
+}
+
+func NewAuditInfo(auditApp application.AuditAppInterface) *AuditInfo {
+	return &AuditInfo{
+		auditApp: auditApp,
+	}
+}
+
+func (auditInfo *AuditInfo) HandleGetAuditLog(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	queryParams := ctx.QueryArgs()
+
+	limitParam := string(queryParams.Peek(string(entity.LimitKey)))
+	limit := 0
+	if limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	query := &entity.AuditQuery{
+		Actor:  string(queryParams.Peek(string(entity.ActorKey))),
+		Forum:  string(queryParams.Peek(string(entity.ForumKey))),
+		Action: string(queryParams.Peek(string(entity.ActionKey))),
+		Limit:  int32(limit),
+	}
+	since := string(queryParams.Peek(string(entity.SinceKey)))
+	until := string(queryParams.Peek(string(entity.UntilKey)))
+	cursor := string(queryParams.Peek(string(entity.CursorKey)))
+
+	auditLog, err := auditInfo.auditApp.GetAuditLog(query, since, until, cursor, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.PermissionDeniedError):
+			status = http.StatusForbidden
+			text = "Only admins can read the audit log"
+		case errors.Is(err, entity.InvalidTimeRangeError):
+			status = http.StatusBadRequest
+			text = "Since and until must be RFC 3339 timestamps"
+		case errors.Is(err, entity.InvalidCursorError):
+			status = http.StatusBadRequest
+			text = "Invalid cursor"
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(auditLog)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
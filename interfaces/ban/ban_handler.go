@@ -0,0 +1,190 @@
+package ban
+
+import (
+	"errors"
+	"fmt"
+	"forum/application"
+	"forum/domain/entity"
+	"forum/interfaces/common"
+	json "github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+	"net/http"
+	"strconv"
+)
+
+type BanInfo struct {
+	banApp application.BanAppInterface
+}
+
+func NewBanInfo(banApp application.BanAppInterface) *BanInfo {
+	return &BanInfo{
+		banApp: banApp,
+	}
+}
+
+func (banInfo *BanInfo) HandleGetBans(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	queryParams := ctx.QueryArgs()
+	activeOnly := string(queryParams.Peek(string(entity.ActiveKey))) == "true"
+
+	bans, err := banInfo.banApp.GetBans(activeOnly, sessionUser.Nickname)
+	if err != nil {
+		if errors.Is(err, entity.PermissionDeniedError) {
+			msg := entity.Message{
+				Text: "Only admins can list bans",
+			}
+			body, err := json.Marshal(msg)
+			if err != nil {
+				ctx.SetStatusCode(http.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetContentType("application/json")
+			ctx.SetStatusCode(http.StatusForbidden)
+			ctx.SetBody(body)
+			return
+		}
+
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(entity.Bans(bans))
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (banInfo *BanInfo) HandleCreateBan(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	ban := &entity.Ban{}
+	err := json.Unmarshal(ctx.Request.Body(), ban)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	err = banInfo.banApp.CreateBan(ban, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.PermissionDeniedError):
+			status = http.StatusForbidden
+			text = "Only admins can ban users"
+		case errors.Is(err, entity.DataError):
+			status = http.StatusBadRequest
+			text = "Nickname of the banned user is required"
+		case errors.Is(err, entity.UserDoesntExistsError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find user with id #%v\n", ban.Nickname)
+		case errors.Is(err, entity.ForumNotExistError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find forum by slug: %v", ban.Forum)
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(ban)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusCreated)
+	ctx.SetBody(body)
+}
+
+func (banInfo *BanInfo) HandleLiftBan(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	banIDInterface := ctx.UserValue("banID")
+	banID := 0
+
+	var err error
+	switch banIDInterface.(type) {
+	case string:
+		banID, err = strconv.Atoi(banIDInterface.(string))
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	ban, err := banInfo.banApp.LiftBan(banID, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.PermissionDeniedError):
+			status = http.StatusForbidden
+			text = "Only admins can lift bans"
+		case errors.Is(err, entity.BanNotFoundError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find active ban with id: %v", banID)
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(ban)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
@@ -0,0 +1,53 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"forum/domain/entity"
+	json "github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+	"net/http"
+)
+
+// WriteMessage answers with status and a Message carrying text
+func WriteMessage(ctx *fasthttp.RequestCtx, status int, text string) {
+	msg := entity.Message{
+		Text: text,
+	}
+	body, err := json.Marshal(msg)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(status)
+	ctx.SetBody(body)
+}
+
+// RequireUser returns the user authMid stored for the session cookie,
+// without one the request is answered with 401 and ok is false
+func RequireUser(ctx *fasthttp.RequestCtx) (*entity.User, bool) {
+	sessionUser, ok := ctx.UserValue(string(entity.CookieInfoKey)).(*entity.User)
+	if !ok {
+		WriteMessage(ctx, http.StatusUnauthorized, "Authorization required")
+		return nil, false
+	}
+
+	return sessionUser, true
+}
+
+// WriteThreadInputError answers the errors about thread input that is not acceptable with 400,
+// forum is the one the thread was meant for. It reports whether err was one of them
+func WriteThreadInputError(ctx *fasthttp.RequestCtx, err error, forum string) bool {
+	switch {
+	case errors.Is(err, entity.InvalidTagsError):
+		WriteMessage(ctx, http.StatusBadRequest,
+			fmt.Sprintf("A thread takes at most %v tags of up to %v characters", entity.MaxThreadTags, entity.MaxTagLength))
+	case errors.Is(err, entity.ForumIsCategoryError):
+		WriteMessage(ctx, http.StatusBadRequest, fmt.Sprintf("Forum %v is a category, threads go into its sub-forums", forum))
+	default:
+		return false
+	}
+	return true
+}
@@ -0,0 +1,47 @@
+package common
+
+import (
+	"forum/domain/entity"
+	"net/http"
+	"testing"
+
+	"github.com/valyala/fasthttp"
+)
+
+func TestRequireUser(t *testing.T) {
+	ctx := &fasthttp.RequestCtx{}
+	user, ok := RequireUser(ctx)
+	if ok || user != nil {
+		t.Fatal("request without a session passed")
+	}
+	if ctx.Response.StatusCode() != http.StatusUnauthorized {
+		t.Errorf("got status %v, want 401", ctx.Response.StatusCode())
+	}
+	if string(ctx.Response.Body()) != `{"message":"Authorization required"}` {
+		t.Errorf("got body %s", ctx.Response.Body())
+	}
+
+	ctx = &fasthttp.RequestCtx{}
+	ctx.SetUserValue(string(entity.CookieInfoKey), &entity.User{Nickname: "alice"})
+	user, ok = RequireUser(ctx)
+	if !ok || user.Nickname != "alice" {
+		t.Errorf("session user was not returned: %v", user)
+	}
+}
+
+func TestWriteThreadInputError(t *testing.T) {
+	for _, err := range []error{entity.InvalidTagsError, entity.ForumIsCategoryError} {
+		ctx := &fasthttp.RequestCtx{}
+		if !WriteThreadInputError(ctx, err, "go") {
+			t.Errorf("%v was not answered", err)
+		}
+		if ctx.Response.StatusCode() != http.StatusBadRequest {
+			t.Errorf("%v: got status %v, want 400", err, ctx.Response.StatusCode())
+		}
+	}
+
+	ctx := &fasthttp.RequestCtx{}
+	if WriteThreadInputError(ctx, entity.ForumNotExistError, "go") {
+		t.Error("ForumNotExistError was answered as bad input")
+	}
+}
@@ -0,0 +1,412 @@
+package conversation
+
+import (
+	"errors"
+	"fmt"
+	"forum/application"
+	"forum/domain/entity"
+	"forum/interfaces/common"
+	json "github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+	"net/http"
+	"strconv"
+)
+
+type ConversationInfo struct {
+	conversationApp application.ConversationAppInterface
+}
+
+func NewConversationInfo(conversationApp application.ConversationAppInterface) *ConversationInfo {
+	return &ConversationInfo{
+		conversationApp: conversationApp,
+	}
+}
+
+func (conversationInfo *ConversationInfo) HandleStartConversation(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	input := &entity.ConversationInput{}
+	err := json.Unmarshal(ctx.Request.Body(), input)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	conversation, err := conversationInfo.conversationApp.StartConversation(input, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.UserDoesntExistsError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find user with id #%v\n", input.With)
+		case errors.Is(err, entity.DataError):
+			status = http.StatusBadRequest
+			text = "You can't do this with yourself"
+		case errors.Is(err, entity.UserBlockedError):
+			status = http.StatusForbidden
+			text = "Messages between you and this user are blocked"
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(conversation)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusCreated)
+	ctx.SetBody(body)
+}
+
+// HandleGetConversations lists the latest conversations first, since is the last message id of the previous page
+func (conversationInfo *ConversationInfo) HandleGetConversations(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	var err error
+	queryParams := ctx.QueryArgs()
+
+	limitParam := string(queryParams.Peek(string(entity.LimitKey)))
+	limit := 0
+	if limitParam != "" {
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	sinceParam := string(queryParams.Peek(string(entity.SinceKey)))
+	since := 0
+	if sinceParam != "" {
+		since, err = strconv.Atoi(sinceParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	conversations, err := conversationInfo.conversationApp.GetConversations(sessionUser.Nickname, int32(limit), since)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(entity.Conversations(conversations))
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (conversationInfo *ConversationInfo) HandleSendMessage(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	conversationIDInterface := ctx.UserValue("conversationID")
+	conversationID := 0
+
+	var err error
+	switch conversationIDInterface.(type) {
+	case string:
+		conversationID, err = strconv.Atoi(conversationIDInterface.(string))
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	input := &entity.PrivateMessageInput{}
+	err = json.Unmarshal(ctx.Request.Body(), input)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	message, err := conversationInfo.conversationApp.SendMessage(conversationID, input, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.ConversationNotFoundError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find conversation with id #%v\n", conversationID)
+		case errors.Is(err, entity.DataError):
+			status = http.StatusBadRequest
+			text = "Message is required"
+		case errors.Is(err, entity.UserBlockedError):
+			status = http.StatusForbidden
+			text = "Messages between you and this user are blocked"
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(message)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusCreated)
+	ctx.SetBody(body)
+}
+
+// HandleGetMessages pages by message id like the thread posts listing
+func (conversationInfo *ConversationInfo) HandleGetMessages(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	conversationIDInterface := ctx.UserValue("conversationID")
+	conversationID := 0
+
+	var err error
+	switch conversationIDInterface.(type) {
+	case string:
+		conversationID, err = strconv.Atoi(conversationIDInterface.(string))
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	queryParams := ctx.QueryArgs()
+
+	limitParam := string(queryParams.Peek(string(entity.LimitKey)))
+	limit := 0
+	if limitParam != "" {
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	sinceParam := string(queryParams.Peek(string(entity.SinceKey)))
+	since := 0
+	if sinceParam != "" {
+		since, err = strconv.Atoi(sinceParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	descParam := string(queryParams.Peek(string(entity.DescKey)))
+	desc := descParam == "true"
+
+	messages, err := conversationInfo.conversationApp.GetMessages(conversationID, int32(limit), since, desc, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.ConversationNotFoundError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find conversation with id #%v\n", conversationID)
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(entity.PrivateMessages(messages))
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (conversationInfo *ConversationInfo) HandleBlockUser(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	nicknameInterface := ctx.UserValue("nickname")
+	var nickname string
+	switch nicknameInterface.(type) {
+	case string:
+		nickname = nicknameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	err := conversationInfo.conversationApp.BlockUser(nickname, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.UserDoesntExistsError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find user with id #%v\n", nickname)
+		case errors.Is(err, entity.DataError):
+			status = http.StatusBadRequest
+			text = "You can't do this with yourself"
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	ctx.SetStatusCode(http.StatusNoContent)
+}
+
+func (conversationInfo *ConversationInfo) HandleUnblockUser(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	nicknameInterface := ctx.UserValue("nickname")
+	var nickname string
+	switch nicknameInterface.(type) {
+	case string:
+		nickname = nicknameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	err := conversationInfo.conversationApp.UnblockUser(nickname, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.UserDoesntExistsError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find user with id #%v\n", nickname)
+		case errors.Is(err, entity.DataError):
+			status = http.StatusBadRequest
+			text = "You can't do this with yourself"
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	ctx.SetStatusCode(http.StatusNoContent)
+}
+
+func (conversationInfo *ConversationInfo) HandleGetBlockedUsers(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	blocked, err := conversationInfo.conversationApp.GetBlockedUsers(sessionUser.Nickname)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(entity.BlockedUsers(blocked))
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
@@ -1,9 +1,11 @@
 package forum
 
 import (
+	"errors"
 	"fmt"
 	"forum/application"
 	"forum/domain/entity"
+	"forum/interfaces/common"
 	json "github.com/mailru/easyjson"
 	"github.com/valyala/fasthttp"
 	"net/http"
@@ -14,17 +16,20 @@ type ForumInfo struct {
 	ForumApp  application.ForumAppInterface
 	UserApp   application.UserAppInterface
 	ThreadApp application.ThreadAppInterface
+	PolicyApp application.PolicyAppInterface
 }
 
 func NewForumInfo(
 	ForumApp application.ForumAppInterface,
 	UserApp application.UserAppInterface,
 	ThreadApp application.ThreadAppInterface,
+	PolicyApp application.PolicyAppInterface,
 ) *ForumInfo {
 	return &ForumInfo{
 		ForumApp:  ForumApp,
 		UserApp:   UserApp,
 		ThreadApp: ThreadApp,
+		PolicyApp: PolicyApp,
 	}
 }
 
@@ -58,6 +63,21 @@ func (forumInfo *ForumInfo) HandleCreateForum(ctx *fasthttp.RequestCtx) {
 
 	err = forumInfo.ForumApp.CreateForum(forum)
 	if err != nil {
+		if errors.Is(err, entity.ForumNotExistError) {
+			msg := entity.Message{
+				Text: fmt.Sprintf("Can't find parent forum by slug: %v", forum.Parent),
+			}
+			body, err := json.Marshal(msg)
+			if err != nil {
+				ctx.SetStatusCode(http.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetContentType("application/json")
+			ctx.SetStatusCode(http.StatusNotFound)
+			ctx.SetBody(body)
+			return
+		}
 
 		existingForum, err := forumInfo.ForumApp.GetForumDetails(forum.Slug)
 		if err != nil {
@@ -117,6 +137,12 @@ func (forumInfo *ForumInfo) HandleGetForumDetails(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
+	forum.Moderators, err = forumInfo.PolicyApp.GetForumModerators(forum.Slug)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
 	body, err := json.Marshal(forum)
 	if err != nil {
 		ctx.SetStatusCode(http.StatusInternalServerError)
@@ -169,6 +195,31 @@ func (forumInfo *ForumInfo) HandleCreateForumThread(ctx *fasthttp.RequestCtx) {
 
 	err = forumInfo.ThreadApp.CreateThread(thread)
 	if err != nil {
+		if errors.Is(err, entity.UserBannedError) || errors.Is(err, entity.UserMutedError) {
+			text := fmt.Sprintf("User %v is banned", thread.Author)
+			if errors.Is(err, entity.UserMutedError) {
+				text = fmt.Sprintf("User %v is muted in forum %v", thread.Author, thread.Forum)
+			}
+
+			msg := entity.Message{
+				Text: text,
+			}
+			body, err := json.Marshal(msg)
+			if err != nil {
+				ctx.SetStatusCode(http.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetContentType("application/json")
+			ctx.SetStatusCode(http.StatusForbidden)
+			ctx.SetBody(body)
+			return
+		}
+
+		if common.WriteThreadInputError(ctx, err, thread.Forum) {
+			return
+		}
+
 		if err == entity.ForumNotExistError {
 			msg := entity.Message{
 				Text: fmt.Sprintf("Can't find thread forum by slug: %v", thread.Forum),
@@ -266,7 +317,10 @@ func (forumInfo *ForumInfo) HandleGetForumUsers(ctx *fasthttp.RequestCtx) {
 	sinceParam := string(queryParams.Peek(string(entity.SinceKey)))
 	since := sinceParam
 
-	users, err := forumInfo.ForumApp.GetForumUsers(slug, int32(limit), since, desc)
+	sortParam := string(queryParams.Peek(string(entity.SortKey)))
+	sort := sortParam
+
+	users, err := forumInfo.ForumApp.GetForumUsers(slug, int32(limit), since, desc, sort)
 	if err != nil {
 		ctx.SetStatusCode(http.StatusInternalServerError)
 		return
@@ -333,12 +387,24 @@ func (forumInfo *ForumInfo) HandleGetForumThreads(ctx *fasthttp.RequestCtx) {
 	sinceParam := string(queryParams.Peek(string(entity.SinceKey)))
 	since := sinceParam
 
-	threads, err := forumInfo.ThreadApp.GetThreadsByForumSlug(slug, int32(limit), since, desc)
+	tagParam := string(queryParams.Peek(string(entity.TagKey)))
+	tag := tagParam
+
+	threads, err := forumInfo.ThreadApp.GetThreadsByForumSlug(slug, int32(limit), since, desc, tag)
 	if err != nil {
 		ctx.SetStatusCode(http.StatusInternalServerError)
 		return
 	}
 
+	sessionUser, ok := ctx.UserValue(string(entity.CookieInfoKey)).(*entity.User)
+	if ok {
+		err = forumInfo.ThreadApp.AddUnreadCounts(threads, sessionUser.Nickname)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+	}
+
 	body, err := json.Marshal(entity.Threads(threads))
 	if err != nil {
 		ctx.SetStatusCode(http.StatusInternalServerError)
@@ -349,3 +415,299 @@ func (forumInfo *ForumInfo) HandleGetForumThreads(ctx *fasthttp.RequestCtx) {
 	ctx.SetStatusCode(http.StatusOK)
 	ctx.SetBody(body)
 }
+
+func (forumInfo *ForumInfo) HandleDeleteForum(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	forumnameInterface := ctx.UserValue("forumname")
+	var slug string
+	switch forumnameInterface.(type) {
+	case string:
+		slug = forumnameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	err := forumInfo.ForumApp.DeleteForum(slug, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.PermissionDeniedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("Only admins and the creator can delete forum %v", slug)
+		case errors.Is(err, entity.ForumHasSubForumsError):
+			status = http.StatusConflict
+			text = fmt.Sprintf("Forum %v still has sub-forums", slug)
+		case errors.Is(err, entity.ForumNotExistError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find forum by slug: %v", slug)
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	ctx.SetStatusCode(http.StatusNoContent)
+}
+
+func (forumInfo *ForumInfo) HandleGetForumModerators(ctx *fasthttp.RequestCtx) {
+	forumnameInterface := ctx.UserValue("forumname")
+
+	var slug string
+	switch forumnameInterface.(type) {
+	case string:
+		slug = forumnameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	forum, err := forumInfo.ForumApp.GetForumDetails(slug)
+	if err != nil {
+		msg := entity.Message{
+			Text: fmt.Sprintf("Can't find forum by slug: %v", slug),
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(http.StatusNotFound)
+		ctx.SetBody(body)
+		return
+	}
+
+	moderators, err := forumInfo.PolicyApp.GetForumModerators(forum.Slug)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(entity.ForumModerators{Forum: forum.Slug, Moderators: moderators})
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (forumInfo *ForumInfo) HandleGrantModerator(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	forumnameInterface := ctx.UserValue("forumname")
+	nicknameInterface := ctx.UserValue("nickname")
+
+	var slug, nickname string
+	switch forumnameInterface.(type) {
+	case string:
+		slug = forumnameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+	switch nicknameInterface.(type) {
+	case string:
+		nickname = nicknameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	moderators, err := forumInfo.PolicyApp.GrantModerator(slug, nickname, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.PermissionDeniedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("Only admins and the creator can grant moderators of forum %v", slug)
+		case errors.Is(err, entity.UserDoesntExistsError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find user with id #%v\n", nickname)
+		default:
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find forum by slug: %v", slug)
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(entity.ForumModerators{Forum: slug, Moderators: moderators})
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (forumInfo *ForumInfo) HandleRevokeModerator(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	forumnameInterface := ctx.UserValue("forumname")
+	nicknameInterface := ctx.UserValue("nickname")
+
+	var slug, nickname string
+	switch forumnameInterface.(type) {
+	case string:
+		slug = forumnameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+	switch nicknameInterface.(type) {
+	case string:
+		nickname = nicknameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	moderators, err := forumInfo.PolicyApp.RevokeModerator(slug, nickname, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.PermissionDeniedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("Only admins and the creator can revoke moderators of forum %v", slug)
+		case errors.Is(err, entity.UserDoesntExistsError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find user with id #%v\n", nickname)
+		default:
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find forum by slug: %v", slug)
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(entity.ForumModerators{Forum: slug, Moderators: moderators})
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (forumInfo *ForumInfo) HandleGetForumTags(ctx *fasthttp.RequestCtx) {
+	forumnameInterface := ctx.UserValue("forumname")
+
+	var slug string
+	switch forumnameInterface.(type) {
+	case string:
+		slug = forumnameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	tags, err := forumInfo.ThreadApp.GetForumTags(slug)
+	if err != nil {
+		if errors.Is(err, entity.ForumNotExistError) {
+			msg := entity.Message{
+				Text: fmt.Sprintf("Can't find forum by slug: %v", slug),
+			}
+			body, err := json.Marshal(msg)
+			if err != nil {
+				ctx.SetStatusCode(http.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetContentType("application/json")
+			ctx.SetStatusCode(http.StatusNotFound)
+			ctx.SetBody(body)
+			return
+		}
+
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(entity.TagCounts(tags))
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (forumInfo *ForumInfo) HandleGetForumTree(ctx *fasthttp.RequestCtx) {
+	tree, err := forumInfo.ForumApp.GetForumTree()
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(entity.Forums(tree))
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
@@ -0,0 +1,145 @@
+package ignore
+
+import (
+	"errors"
+	"fmt"
+	"forum/application"
+	"forum/domain/entity"
+	"forum/interfaces/common"
+	json "github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+	"net/http"
+)
+
+type IgnoreInfo struct {
+	ignoreApp application.IgnoreAppInterface
+}
+
+func NewIgnoreInfo(ignoreApp application.IgnoreAppInterface) *IgnoreInfo {
+	return &IgnoreInfo{
+		ignoreApp: ignoreApp,
+	}
+}
+
+func (ignoreInfo *IgnoreInfo) HandleIgnoreUser(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	nicknameInterface := ctx.UserValue("nickname")
+	var nickname string
+	switch nicknameInterface.(type) {
+	case string:
+		nickname = nicknameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	err := ignoreInfo.ignoreApp.IgnoreUser(nickname, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.UserDoesntExistsError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find user with id #%v\n", nickname)
+		case errors.Is(err, entity.DataError):
+			status = http.StatusBadRequest
+			text = "You can't do this with yourself"
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	ctx.SetStatusCode(http.StatusNoContent)
+}
+
+func (ignoreInfo *IgnoreInfo) HandleUnignoreUser(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	nicknameInterface := ctx.UserValue("nickname")
+	var nickname string
+	switch nicknameInterface.(type) {
+	case string:
+		nickname = nicknameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	err := ignoreInfo.ignoreApp.UnignoreUser(nickname, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.UserDoesntExistsError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find user with id #%v\n", nickname)
+		case errors.Is(err, entity.DataError):
+			status = http.StatusBadRequest
+			text = "You can't do this with yourself"
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	ctx.SetStatusCode(http.StatusNoContent)
+}
+
+func (ignoreInfo *IgnoreInfo) HandleGetIgnoredUsers(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	ignored, err := ignoreInfo.ignoreApp.GetIgnoredUsers(sessionUser.Nickname)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(entity.IgnoredUsers(ignored))
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
@@ -0,0 +1,200 @@
+package live
+
+import (
+	"forum/application"
+	"forum/domain/entity"
+	"forum/domain/repository"
+	"github.com/fasthttp/websocket"
+	json "github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const pingInterval = 30 * time.Second
+const pongWait = 2 * pingInterval
+const writeWait = 10 * time.Second
+const maxMessageSize = 4096
+
+// maxSubscriptions caps the forums and threads a single connection follows
+const maxSubscriptions = 100
+
+// forum events are public, pages of any origin may listen to them
+var upgrader = websocket.FastHTTPUpgrader{
+	CheckOrigin: func(ctx *fasthttp.RequestCtx) bool {
+		return true
+	},
+}
+
+type LiveInfo struct {
+	liveApp application.LiveAppInterface
+}
+
+func NewLiveInfo(liveApp application.LiveAppInterface) *LiveInfo {
+	return &LiveInfo{
+		liveApp: liveApp,
+	}
+}
+
+// HandleLive upgrades the request to a WebSocket delivering forum events.
+// Initial forum and thread may be passed as query parameters,
+// later ones are (un)subscribed with LiveSubscription messages.
+func (liveInfo *LiveInfo) HandleLive(ctx *fasthttp.RequestCtx) {
+	queryParams := ctx.QueryArgs()
+	forum := string(queryParams.Peek("forum"))
+
+	threadParam := string(queryParams.Peek("thread"))
+	threadID := 0
+	if threadParam != "" {
+		var err error
+		threadID, err = strconv.Atoi(threadParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	// a failed handshake is answered by the upgrader itself
+	_ = upgrader.Upgrade(ctx, func(conn *websocket.Conn) {
+		subscription := liveInfo.liveApp.Subscribe()
+		defer subscription.Close()
+
+		following := followed{}
+		following.add(forum, threadID)
+		subscription.Follow(forum, threadID)
+		liveInfo.serve(conn, subscription, following)
+	})
+}
+
+// serve writes events until the client disconnects or falls too far behind
+func (liveInfo *LiveInfo) serve(conn *websocket.Conn, subscription repository.EventSubscription, following followed) {
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		liveInfo.readLoop(conn, subscription, following)
+	}()
+
+	ping := time.NewTicker(pingInterval)
+	defer ping.Stop()
+
+	for {
+		select {
+		case event, ok := <-subscription.Events():
+			if !ok {
+				_ = writeClose(conn, websocket.CloseTryAgainLater, "too slow to keep up with events")
+				return
+			}
+
+			body, err := json.Marshal(event)
+			if err != nil {
+				continue
+			}
+			err = conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err != nil {
+				return
+			}
+			err = conn.WriteMessage(websocket.TextMessage, body)
+			if err != nil {
+				return
+			}
+		case <-ping.C:
+			err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(writeWait))
+			if err != nil {
+				return
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// readLoop handles subscription requests, a client that does not answer pings within pongWait is considered gone.
+// Pings, close frames, protocol errors and oversized messages are answered by the websocket package
+func (liveInfo *LiveInfo) readLoop(conn *websocket.Conn, subscription repository.EventSubscription, following followed) {
+	conn.SetReadLimit(maxMessageSize)
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		err := conn.SetReadDeadline(time.Now().Add(pongWait))
+		if err != nil {
+			return
+		}
+
+		messageType, payload, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if messageType != websocket.TextMessage {
+			_ = writeClose(conn, websocket.CloseUnsupportedData, "only text messages are supported")
+			return
+		}
+
+		request := &entity.LiveSubscription{}
+		err = json.Unmarshal(payload, request)
+		if err != nil {
+			continue
+		}
+
+		switch request.Action {
+		case entity.SubscribeAction:
+			if !following.add(request.Forum, request.Thread) {
+				_ = writeClose(conn, websocket.ClosePolicyViolation,
+					"a connection follows at most "+strconv.Itoa(maxSubscriptions)+" forums and threads")
+				return
+			}
+			subscription.Follow(request.Forum, request.Thread)
+		case entity.UnsubscribeAction:
+			following.remove(request.Forum, request.Thread)
+			subscription.Unfollow(request.Forum, request.Thread)
+		}
+	}
+}
+
+// writeClose is safe to call while serve writes events
+func writeClose(conn *websocket.Conn, code int, reason string) error {
+	return conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason), time.Now().Add(writeWait))
+}
+
+// followed mirrors the forums and threads the subscription of a connection follows, to hold it to maxSubscriptions;
+// only the read loop touches it once the connection is served
+type followed map[string]struct{}
+
+func followKeys(forum string, threadID int) []string {
+	var keys []string
+	if forum != "" {
+		keys = append(keys, "forum "+strings.ToLower(forum))
+	}
+	if threadID != 0 {
+		keys = append(keys, "thread "+strconv.Itoa(threadID))
+	}
+	return keys
+}
+
+// add reports false and follows nothing if that would go past maxSubscriptions
+func (f followed) add(forum string, threadID int) bool {
+	keys := followKeys(forum, threadID)
+	count := len(f)
+	for _, key := range keys {
+		if _, ok := f[key]; !ok {
+			count++
+		}
+	}
+	if count > maxSubscriptions {
+		return false
+	}
+
+	for _, key := range keys {
+		f[key] = struct{}{}
+	}
+	return true
+}
+
+func (f followed) remove(forum string, threadID int) {
+	for _, key := range followKeys(forum, threadID) {
+		delete(f, key)
+	}
+}
@@ -0,0 +1,91 @@
+package live
+
+import (
+	"forum/domain/entity"
+	"forum/domain/repository"
+	"net"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/fasthttp/websocket"
+	"github.com/valyala/fasthttp"
+	"github.com/valyala/fasthttp/fasthttputil"
+)
+
+type testSubscription struct {
+	mu      sync.Mutex
+	events  chan *entity.ForumEvent
+	threads map[int]bool
+}
+
+func (s *testSubscription) Events() <-chan *entity.ForumEvent {
+	return s.events
+}
+
+func (s *testSubscription) Follow(forum string, threadID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.threads[threadID] = true
+}
+
+func (s *testSubscription) Unfollow(forum string, threadID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.threads, threadID)
+}
+
+func (s *testSubscription) Close() {}
+
+type testLiveApp struct {
+	subscription *testSubscription
+}
+
+func (a *testLiveApp) Subscribe() repository.EventSubscription {
+	return a.subscription
+}
+
+func dialLive(t *testing.T, liveInfo *LiveInfo) *websocket.Conn {
+	ln := fasthttputil.NewInmemoryListener()
+	t.Cleanup(func() { ln.Close() })
+	go fasthttp.Serve(ln, liveInfo.HandleLive)
+
+	dialer := websocket.Dialer{NetDial: func(network, addr string) (net.Conn, error) {
+		return ln.Dial()
+	}}
+	conn, _, err := dialer.Dial("ws://forum/live?thread=1", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func TestLiveSubscriptionLimit(t *testing.T) {
+	subscription := &testSubscription{events: make(chan *entity.ForumEvent), threads: make(map[int]bool)}
+	conn := dialLive(t, NewLiveInfo(&testLiveApp{subscription: subscription}))
+
+	// thread 1 from the query counts against the limit, following it again does not
+	for id := 1; id <= maxSubscriptions; id++ {
+		err := conn.WriteMessage(websocket.TextMessage,
+			[]byte(`{"action":"subscribe","thread":`+strconv.Itoa(id)+`}`))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := conn.WriteMessage(websocket.TextMessage, []byte(`{"action":"subscribe","thread":100000}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err = conn.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.ClosePolicyViolation) {
+		t.Fatalf("got %v, want a policy violation close", err)
+	}
+
+	subscription.mu.Lock()
+	defer subscription.mu.Unlock()
+	if len(subscription.threads) != maxSubscriptions || subscription.threads[100000] {
+		t.Errorf("subscription follows %v threads, want %v", len(subscription.threads), maxSubscriptions)
+	}
+}
@@ -0,0 +1,174 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"forum/application"
+	"forum/domain/entity"
+	"forum/interfaces/common"
+	json "github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+	"net/http"
+	"strconv"
+)
+
+type NotificationInfo struct {
+	notificationApp application.NotificationAppInterface
+}
+
+func NewNotificationInfo(notificationApp application.NotificationAppInterface) *NotificationInfo {
+	return &NotificationInfo{
+		notificationApp: notificationApp,
+	}
+}
+
+func (notificationInfo *NotificationInfo) HandleGetNotifications(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	queryParams := ctx.QueryArgs()
+
+	var err error
+	limitParam := string(queryParams.Peek(string(entity.LimitKey)))
+	limit := 0
+	if limitParam != "" {
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	sinceParam := string(queryParams.Peek(string(entity.SinceKey)))
+	since := 0
+	if sinceParam != "" {
+		since, err = strconv.Atoi(sinceParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	descParam := string(queryParams.Peek(string(entity.DescKey)))
+	desc := descParam == "true"
+
+	unreadParam := string(queryParams.Peek(string(entity.UnreadKey)))
+	unread := unreadParam == "true"
+
+	notifications, err := notificationInfo.notificationApp.GetNotifications(sessionUser.Nickname, int32(limit), since, desc, unread)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(entity.Notifications(notifications))
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (notificationInfo *NotificationInfo) HandleGetNotification(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	notificationIDInterface := ctx.UserValue("notificationID")
+	notificationID := 0
+
+	var err error
+	switch notificationIDInterface.(type) {
+	case string:
+		notificationID, err = strconv.Atoi(notificationIDInterface.(string))
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	notification, err := notificationInfo.notificationApp.GetNotification(sessionUser.Nickname, notificationID)
+	if err != nil {
+		if errors.Is(err, entity.NotificationNotFoundError) {
+			msg := entity.Message{
+				Text: fmt.Sprintf("Can't find notification with id: %v", notificationID),
+			}
+			body, err := json.Marshal(msg)
+			if err != nil {
+				ctx.SetStatusCode(http.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetContentType("application/json")
+			ctx.SetStatusCode(http.StatusNotFound)
+			ctx.SetBody(body)
+			return
+		}
+
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(notification)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (notificationInfo *NotificationInfo) HandleMarkNotificationsRead(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	input := &entity.NotificationReadInput{}
+	err := json.Unmarshal(ctx.Request.Body(), input)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	err = notificationInfo.notificationApp.MarkRead(sessionUser.Nickname, input)
+	if err != nil {
+		var status int
+		switch {
+		case errors.Is(err, entity.UnknownNotificationTypeError):
+			status = http.StatusBadRequest
+		case errors.Is(err, entity.NotificationNotFoundError):
+			status = http.StatusNotFound
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: err.Error(),
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	ctx.SetStatusCode(http.StatusOK)
+}
@@ -1,9 +1,11 @@
 package post
 
 import (
+	"errors"
 	"fmt"
 	"forum/application"
 	"forum/domain/entity"
+	"forum/interfaces/common"
 	json "github.com/mailru/easyjson"
 	"github.com/valyala/fasthttp"
 	"net/http"
@@ -16,6 +18,7 @@ type PostInfo struct {
 	UserApp   application.UserAppInterface
 	ThreadApp application.ThreadAppInterface
 	ForumApp  application.ForumAppInterface
+	ignoreApp application.IgnoreAppInterface
 }
 
 func NewPostInfo(
@@ -23,12 +26,14 @@ func NewPostInfo(
 	UserApp application.UserAppInterface,
 	ThreadApp application.ThreadAppInterface,
 	ForumApp application.ForumAppInterface,
+	ignoreApp application.IgnoreAppInterface,
 ) *PostInfo {
 	return &PostInfo{
 		PostApp:   PostApp,
 		UserApp:   UserApp,
 		ThreadApp: ThreadApp,
 		ForumApp:  ForumApp,
+		ignoreApp: ignoreApp,
 	}
 }
 
@@ -66,6 +71,17 @@ func (postInfo *PostInfo) HandleGetPostDetails(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
+	sessionUser, ok := ctx.UserValue(string(entity.CookieInfoKey)).(*entity.User)
+	if ok {
+		posts := []entity.Post{*post}
+		err = postInfo.ignoreApp.CollapseIgnoredPosts(posts, sessionUser.Nickname)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+		*post = posts[0]
+	}
+
 	postInformation := entity.PostOutput{
 		Post: post,
 	}
@@ -135,6 +151,15 @@ func (postInfo *PostInfo) HandleGetPostDetails(ctx *fasthttp.RequestCtx) {
 		postInformation.Forum = forum
 	}
 
+	if strings.Contains(related, "history") && !post.IsDeleted && !post.IsIgnored {
+		history, err := postInfo.PostApp.GetPostRevisions(postID)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+		postInformation.History = history
+	}
+
 	body, err := json.Marshal(postInformation)
 	if err != nil {
 		ctx.SetStatusCode(http.StatusInternalServerError)
@@ -148,6 +173,11 @@ func (postInfo *PostInfo) HandleGetPostDetails(ctx *fasthttp.RequestCtx) {
 }
 
 func (postInfo *PostInfo) HandleChangePost(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
 	postIDInterface := ctx.UserValue("postID")
 	postID := 0
 
@@ -200,9 +230,37 @@ func (postInfo *PostInfo) HandleChangePost(ctx *fasthttp.RequestCtx) {
 		return
 	}
 	post.ID = postID
+	post.Author = sessionUser.Nickname
 
 	post, err = postInfo.PostApp.ChangePostMessage(post)
 	if err != nil {
+		if errors.Is(err, entity.PermissionDeniedError) || errors.Is(err, entity.ThreadArchivedError) ||
+			errors.Is(err, entity.UserBannedError) || errors.Is(err, entity.UserMutedError) {
+			text := fmt.Sprintf("Post %v can only be edited by its author or a moderator", postID)
+			switch {
+			case errors.Is(err, entity.ThreadArchivedError):
+				text = fmt.Sprintf("Post %v belongs to an archived thread and is read-only", postID)
+			case errors.Is(err, entity.UserBannedError):
+				text = fmt.Sprintf("User %v is banned", sessionUser.Nickname)
+			case errors.Is(err, entity.UserMutedError):
+				text = fmt.Sprintf("User %v is muted in the forum of post %v", sessionUser.Nickname, postID)
+			}
+
+			msg := entity.Message{
+				Text: text,
+			}
+			body, err := json.Marshal(msg)
+			if err != nil {
+				ctx.SetStatusCode(http.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetContentType("application/json")
+			ctx.SetStatusCode(http.StatusForbidden)
+			ctx.SetBody(body)
+			return
+		}
+
 		msg := entity.Message{
 			Text: fmt.Sprintf("Can't find post with id: %v", postID),
 		}
@@ -229,3 +287,395 @@ func (postInfo *PostInfo) HandleChangePost(ctx *fasthttp.RequestCtx) {
 	ctx.SetBody(body)
 	return
 }
+
+func (postInfo *PostInfo) HandleDeletePost(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	postIDInterface := ctx.UserValue("postID")
+	postID := 0
+
+	var err error
+	switch postIDInterface.(type) {
+	case string:
+		postID, err = strconv.Atoi(postIDInterface.(string))
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	err = postInfo.PostApp.DeletePost(postID, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.PermissionDeniedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("Post %v can only be deleted by its author or a moderator", postID)
+		case errors.Is(err, entity.ThreadArchivedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("Post %v belongs to an archived thread and is read-only", postID)
+		case errors.Is(err, entity.UserBannedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("User %v is banned", sessionUser.Nickname)
+		case errors.Is(err, entity.UserMutedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("User %v is muted in the forum of post %v", sessionUser.Nickname, postID)
+		default:
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find post with id: %v", postID)
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	ctx.SetStatusCode(http.StatusNoContent)
+	return
+}
+
+func (postInfo *PostInfo) HandleRestorePost(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	postIDInterface := ctx.UserValue("postID")
+	postID := 0
+
+	var err error
+	switch postIDInterface.(type) {
+	case string:
+		postID, err = strconv.Atoi(postIDInterface.(string))
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	post, err := postInfo.PostApp.RestorePost(postID, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.PermissionDeniedError):
+			status = http.StatusForbidden
+			text = "Only admins can restore deleted posts"
+		case errors.Is(err, entity.PostNotFoundError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find post with id: %v", postID)
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(post)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+	return
+}
+
+func (postInfo *PostInfo) HandleGetPostHistory(ctx *fasthttp.RequestCtx) {
+	postIDInterface := ctx.UserValue("postID")
+	postID := 0
+
+	var err error
+	switch postIDInterface.(type) {
+	case string:
+		postID, err = strconv.Atoi(postIDInterface.(string))
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	queryParams := ctx.QueryArgs()
+
+	var from, to int
+	fromParam := string(queryParams.Peek(string(entity.FromKey)))
+	if fromParam != "" {
+		from, err = strconv.Atoi(fromParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+	toParam := string(queryParams.Peek(string(entity.ToKey)))
+	if toParam != "" {
+		to, err = strconv.Atoi(toParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	history, err := postInfo.PostApp.GetPostHistory(postID, from, to)
+	if err != nil {
+		var text string
+		switch {
+		case errors.Is(err, entity.RevisionNotFoundError):
+			text = fmt.Sprintf("Post %v has no such revision", postID)
+		default:
+			text = fmt.Sprintf("Can't find post with id: %v", postID)
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(http.StatusNotFound)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(history)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+	return
+}
+
+func (postInfo *PostInfo) HandleAddPostReaction(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	postIDInterface := ctx.UserValue("postID")
+	postID := 0
+
+	var err error
+	switch postIDInterface.(type) {
+	case string:
+		postID, err = strconv.Atoi(postIDInterface.(string))
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	input := &entity.ReactionInput{}
+	err = json.Unmarshal(ctx.Request.Body(), input)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	post, err := postInfo.PostApp.AddReaction(postID, input.Reaction, sessionUser.Nickname)
+	postInfo.writeReactionResult(ctx, postID, input.Reaction, sessionUser.Nickname, post, err)
+}
+
+func (postInfo *PostInfo) HandleRemovePostReaction(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	postIDInterface := ctx.UserValue("postID")
+	postID := 0
+
+	var err error
+	switch postIDInterface.(type) {
+	case string:
+		postID, err = strconv.Atoi(postIDInterface.(string))
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	reaction := string(ctx.QueryArgs().Peek(string(entity.ReactionKey)))
+
+	post, err := postInfo.PostApp.RemoveReaction(postID, reaction, sessionUser.Nickname)
+	postInfo.writeReactionResult(ctx, postID, reaction, sessionUser.Nickname, post, err)
+}
+
+// writeReactionResult answers both reaction handlers with the post and its updated counts
+func (postInfo *PostInfo) writeReactionResult(ctx *fasthttp.RequestCtx, postID int, reaction string, nickname string, post *entity.Post, err error) {
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.UnknownReactionError):
+			status = http.StatusBadRequest
+			text = fmt.Sprintf("Unknown reaction: %v", reaction)
+		case errors.Is(err, entity.ThreadArchivedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("Post %v belongs to an archived thread and is read-only", postID)
+		case errors.Is(err, entity.UserBannedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("User %v is banned", nickname)
+		case errors.Is(err, entity.UserMutedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("User %v is muted in the forum of post %v", nickname, postID)
+		default:
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find post with id: %v", postID)
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(post)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (postInfo *PostInfo) HandleVoteForPost(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	postIDInterface := ctx.UserValue("postID")
+	postID := 0
+
+	var err error
+	switch postIDInterface.(type) {
+	case string:
+		postID, err = strconv.Atoi(postIDInterface.(string))
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	vote := &entity.Vote{}
+	err = json.Unmarshal(ctx.Request.Body(), vote)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	vote.Nickname = sessionUser.Nickname
+	vote.ID = postID
+
+	post, err := postInfo.PostApp.VoteForPost(vote)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.InvalidVoteError):
+			status = http.StatusBadRequest
+			text = "Voice must be -1, 0 or 1"
+		case errors.Is(err, entity.ThreadArchivedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("Post %v belongs to an archived thread, votes are not accepted", postID)
+		case errors.Is(err, entity.UserBannedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("User %v is banned", sessionUser.Nickname)
+		case errors.Is(err, entity.UserMutedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("User %v is muted in the forum of post %v", sessionUser.Nickname, postID)
+		default:
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find post with id: %v", postID)
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(post)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
@@ -0,0 +1,305 @@
+package report
+
+import (
+	"errors"
+	"fmt"
+	"forum/application"
+	"forum/domain/entity"
+	"forum/interfaces/common"
+	json "github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+	"net/http"
+	"strconv"
+)
+
+type ReportInfo struct {
+	reportApp application.ReportAppInterface
+}
+
+func NewReportInfo(reportApp application.ReportAppInterface) *ReportInfo {
+	return &ReportInfo{
+		reportApp: reportApp,
+	}
+}
+
+func (reportInfo *ReportInfo) HandleReportPost(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	postIDInterface := ctx.UserValue("postID")
+	postID := 0
+
+	var err error
+	switch postIDInterface.(type) {
+	case string:
+		postID, err = strconv.Atoi(postIDInterface.(string))
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	input := &entity.ReportInput{}
+	err = json.Unmarshal(ctx.Request.Body(), input)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	report, err := reportInfo.reportApp.ReportPost(postID, input, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.UnknownReportReasonError):
+			status = http.StatusBadRequest
+			text = entity.UnknownReportReasonError.Error()
+		case errors.Is(err, entity.ReportAlreadyExistsError):
+			status = http.StatusConflict
+			text = entity.ReportAlreadyExistsError.Error()
+		case errors.Is(err, entity.PostNotFoundError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find post with id: %v", postID)
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusCreated)
+	ctx.SetBody(body)
+}
+
+func (reportInfo *ReportInfo) HandleReportThread(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	forumnameInterface := ctx.UserValue("threadnameOrID")
+	var slug string
+	switch forumnameInterface.(type) {
+	case string:
+		slug = forumnameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	input := &entity.ReportInput{}
+	err := json.Unmarshal(ctx.Request.Body(), input)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	report, err := reportInfo.reportApp.ReportThread(slug, input, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.UnknownReportReasonError):
+			status = http.StatusBadRequest
+			text = entity.UnknownReportReasonError.Error()
+		case errors.Is(err, entity.ReportAlreadyExistsError):
+			status = http.StatusConflict
+			text = entity.ReportAlreadyExistsError.Error()
+		case errors.Is(err, entity.ThreadNotFoundError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find thread by slug: %v", slug)
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusCreated)
+	ctx.SetBody(body)
+}
+
+func (reportInfo *ReportInfo) HandleGetReportQueue(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	forumnameInterface := ctx.UserValue("forumname")
+	var slug string
+	switch forumnameInterface.(type) {
+	case string:
+		slug = forumnameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	queryParams := ctx.QueryArgs()
+
+	limitParam := string(queryParams.Peek(string(entity.LimitKey)))
+	limit := 0
+	if limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	queue, err := reportInfo.reportApp.GetReportQueue(slug, int32(limit), sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.PermissionDeniedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("Only moderators can see the moderation queue of forum %v", slug)
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(entity.ReportQueue(queue))
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (reportInfo *ReportInfo) HandleResolveReports(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	forumnameInterface := ctx.UserValue("forumname")
+	var slug string
+	switch forumnameInterface.(type) {
+	case string:
+		slug = forumnameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	resolution := &entity.ReportResolution{}
+	err := json.Unmarshal(ctx.Request.Body(), resolution)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	err = reportInfo.reportApp.ResolveReports(slug, resolution, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.PermissionDeniedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("Only moderators can resolve reports in forum %v", slug)
+		case errors.Is(err, entity.UnknownResolutionError):
+			status = http.StatusBadRequest
+			text = entity.UnknownResolutionError.Error()
+		case errors.Is(err, entity.ThreadArchivedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("%v %v belongs to an archived thread and is read-only", resolution.Type, resolution.Target)
+		case errors.Is(err, entity.ReportNotFoundError), errors.Is(err, entity.PostNotFoundError),
+			errors.Is(err, entity.ThreadNotFoundError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("No open reports on %v %v in forum %v", resolution.Type, resolution.Target, slug)
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(resolution)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
@@ -0,0 +1,77 @@
+package search
+
+import (
+	"errors"
+	"forum/application"
+	"forum/domain/entity"
+	json "github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+	"net/http"
+	"strconv"
+)
+
+type SearchInfo struct {
+	searchApp application.SearchAppInterface
+}
+
+func NewSearchInfo(searchApp application.SearchAppInterface) *SearchInfo {
+	return &SearchInfo{
+		searchApp: searchApp,
+	}
+}
+
+func (searchInfo *SearchInfo) HandleSearch(ctx *fasthttp.RequestCtx) {
+	queryParams := ctx.QueryArgs()
+
+	limitParam := string(queryParams.Peek(string(entity.LimitKey)))
+	limit := 0
+	if limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	results, err := searchInfo.searchApp.Search(
+		string(queryParams.Peek(string(entity.QueryKey))),
+		string(queryParams.Peek(string(entity.ForumKey))),
+		string(queryParams.Peek(string(entity.AuthorKey))),
+		string(queryParams.Peek(string(entity.TypeKey))),
+		int32(limit),
+		string(queryParams.Peek(string(entity.CursorKey))),
+	)
+	if err != nil {
+		if errors.Is(err, entity.EmptySearchQueryError) ||
+			errors.Is(err, entity.UnknownSearchTypeError) ||
+			errors.Is(err, entity.InvalidCursorError) {
+			msg := entity.Message{
+				Text: err.Error(),
+			}
+			body, err := json.Marshal(msg)
+			if err != nil {
+				ctx.SetStatusCode(http.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetContentType("application/json")
+			ctx.SetStatusCode(http.StatusBadRequest)
+			ctx.SetBody(body)
+			return
+		}
+
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(results)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
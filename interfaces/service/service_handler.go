@@ -20,7 +20,13 @@ func NewServiceInfo(ServiceApp application.ServiceAppInterface) *ServiceInfo {
 }
 
 func (serviceInfo *ServiceInfo) HandleClearData(ctx *fasthttp.RequestCtx) {
-	err := serviceInfo.ServiceApp.ClearAllDate()
+	actor := entity.AuditAnonymousActor
+	sessionUser, ok := ctx.UserValue(string(entity.CookieInfoKey)).(*entity.User)
+	if ok {
+		actor = sessionUser.Nickname
+	}
+
+	err := serviceInfo.ServiceApp.ClearAllDate(actor)
 	if err != nil {
 		msg := entity.Message{
 			Text: fmt.Sprintf(`{"messege": "%s"}`, err.Error()),
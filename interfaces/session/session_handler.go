@@ -0,0 +1,118 @@
+package session
+
+import (
+	"errors"
+	"forum/application"
+	"forum/domain/entity"
+	"forum/interfaces/common"
+	json "github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+	"net/http"
+)
+
+type SessionInfo struct {
+	sessionApp application.SessionAppInterface
+	userApp    application.UserAppInterface
+}
+
+func NewSessionInfo(
+	sessionApp application.SessionAppInterface,
+	userApp application.UserAppInterface,
+) *SessionInfo {
+	return &SessionInfo{
+		sessionApp: sessionApp,
+		userApp:    userApp,
+	}
+}
+
+func (sessionInfo *SessionInfo) HandleLogin(ctx *fasthttp.RequestCtx) {
+	credentials := &entity.Credentials{}
+	err := json.Unmarshal(ctx.Request.Body(), credentials)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	session, err := sessionInfo.sessionApp.Login(credentials)
+	if err != nil {
+		// an unknown nickname gets the same answer as a wrong password, so logins can not probe for accounts
+		if errors.Is(err, entity.UserDoesntExistsError) || errors.Is(err, entity.WrongPasswordError) ||
+			errors.Is(err, entity.PasswordNotSetError) {
+			msg := entity.Message{
+				Text: "Wrong nickname or password",
+			}
+			body, err := json.Marshal(msg)
+			if err != nil {
+				ctx.SetStatusCode(http.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetContentType("application/json")
+			ctx.SetStatusCode(http.StatusUnauthorized)
+			ctx.SetBody(body)
+			return
+		}
+
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	profile, err := sessionInfo.userApp.GetUserByNickname(session.Nickname)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(profile)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	cookie := fasthttp.AcquireCookie()
+	defer fasthttp.ReleaseCookie(cookie)
+	cookie.SetKey(entity.CookieNameKey)
+	cookie.SetValue(session.ID)
+	cookie.SetExpire(session.Expires)
+	cookie.SetPath("/")
+	cookie.SetHTTPOnly(true)
+	ctx.Response.Header.SetCookie(cookie)
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (sessionInfo *SessionInfo) HandleLogout(ctx *fasthttp.RequestCtx) {
+	sessionID := string(ctx.Request.Header.Cookie(entity.CookieNameKey))
+	if sessionID == "" {
+		ctx.SetStatusCode(http.StatusUnauthorized)
+		return
+	}
+
+	err := sessionInfo.sessionApp.Logout(sessionID)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.Response.Header.DelClientCookie(entity.CookieNameKey)
+	ctx.SetStatusCode(http.StatusOK)
+}
+
+func (sessionInfo *SessionInfo) HandleGetSession(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	body, err := json.Marshal(sessionUser)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
@@ -0,0 +1,303 @@
+package subscription
+
+import (
+	"errors"
+	"fmt"
+	"forum/application"
+	"forum/domain/entity"
+	"forum/interfaces/common"
+	json "github.com/mailru/easyjson"
+	"github.com/valyala/fasthttp"
+	"net/http"
+	"strconv"
+)
+
+type SubscriptionInfo struct {
+	subscriptionApp application.SubscriptionAppInterface
+}
+
+func NewSubscriptionInfo(subscriptionApp application.SubscriptionAppInterface) *SubscriptionInfo {
+	return &SubscriptionInfo{
+		subscriptionApp: subscriptionApp,
+	}
+}
+
+func (subscriptionInfo *SubscriptionInfo) HandleSubscribeThread(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	slugInterface := ctx.UserValue("threadnameOrID")
+	var slug string
+	switch slugInterface.(type) {
+	case string:
+		slug = slugInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	err := subscriptionInfo.subscriptionApp.SubscribeThread(slug, sessionUser.Nickname)
+	if err != nil {
+		msg := entity.Message{
+			Text: fmt.Sprintf("Can't find thread by slug: %v", slug),
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(http.StatusNotFound)
+		ctx.SetBody(body)
+		return
+	}
+
+	ctx.SetStatusCode(http.StatusNoContent)
+}
+
+func (subscriptionInfo *SubscriptionInfo) HandleUnsubscribeThread(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	slugInterface := ctx.UserValue("threadnameOrID")
+	var slug string
+	switch slugInterface.(type) {
+	case string:
+		slug = slugInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	err := subscriptionInfo.subscriptionApp.UnsubscribeThread(slug, sessionUser.Nickname)
+	if err != nil {
+		msg := entity.Message{
+			Text: fmt.Sprintf("Can't find thread by slug: %v", slug),
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(http.StatusNotFound)
+		ctx.SetBody(body)
+		return
+	}
+
+	ctx.SetStatusCode(http.StatusNoContent)
+}
+
+func (subscriptionInfo *SubscriptionInfo) HandleSubscribeForum(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	slugInterface := ctx.UserValue("forumname")
+	var slug string
+	switch slugInterface.(type) {
+	case string:
+		slug = slugInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	err := subscriptionInfo.subscriptionApp.SubscribeForum(slug, sessionUser.Nickname)
+	if err != nil {
+		msg := entity.Message{
+			Text: fmt.Sprintf("Can't find forum by slug: %v", slug),
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(http.StatusNotFound)
+		ctx.SetBody(body)
+		return
+	}
+
+	ctx.SetStatusCode(http.StatusNoContent)
+}
+
+func (subscriptionInfo *SubscriptionInfo) HandleUnsubscribeForum(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	slugInterface := ctx.UserValue("forumname")
+	var slug string
+	switch slugInterface.(type) {
+	case string:
+		slug = slugInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	err := subscriptionInfo.subscriptionApp.UnsubscribeForum(slug, sessionUser.Nickname)
+	if err != nil {
+		msg := entity.Message{
+			Text: fmt.Sprintf("Can't find forum by slug: %v", slug),
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(http.StatusNotFound)
+		ctx.SetBody(body)
+		return
+	}
+
+	ctx.SetStatusCode(http.StatusNoContent)
+}
+
+// HandleGetFeed pages with the cursor of the previous page, since is a timestamp for the first one
+func (subscriptionInfo *SubscriptionInfo) HandleGetFeed(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	nicknameInterface := ctx.UserValue("username")
+	var nickname string
+	switch nicknameInterface.(type) {
+	case string:
+		nickname = nicknameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	queryParams := ctx.QueryArgs()
+
+	limitParam := string(queryParams.Peek(string(entity.LimitKey)))
+	limit := 0
+	if limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	descParam := string(queryParams.Peek(string(entity.DescKey)))
+	desc := descParam == "true"
+
+	sinceParam := string(queryParams.Peek(string(entity.SinceKey)))
+	since := sinceParam
+
+	cursor := string(queryParams.Peek(string(entity.CursorKey)))
+
+	feed, err := subscriptionInfo.subscriptionApp.GetFeed(nickname, int32(limit), since, cursor, desc, sessionUser.Nickname)
+	if err != nil {
+		if errors.Is(err, entity.InvalidCursorError) {
+			msg := entity.Message{
+				Text: "Invalid cursor",
+			}
+			body, err := json.Marshal(msg)
+			if err != nil {
+				ctx.SetStatusCode(http.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetContentType("application/json")
+			ctx.SetStatusCode(http.StatusBadRequest)
+			ctx.SetBody(body)
+			return
+		}
+
+		if errors.Is(err, entity.PermissionDeniedError) {
+			msg := entity.Message{
+				Text: fmt.Sprintf("Only %v can see their feed", nickname),
+			}
+			body, err := json.Marshal(msg)
+			if err != nil {
+				ctx.SetStatusCode(http.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetContentType("application/json")
+			ctx.SetStatusCode(http.StatusForbidden)
+			ctx.SetBody(body)
+			return
+		}
+
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(feed)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (subscriptionInfo *SubscriptionInfo) HandleGetSubscriptions(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	nicknameInterface := ctx.UserValue("username")
+	var nickname string
+	switch nicknameInterface.(type) {
+	case string:
+		nickname = nicknameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	subscriptions, err := subscriptionInfo.subscriptionApp.GetSubscriptions(nickname, sessionUser.Nickname)
+	if err != nil {
+		if errors.Is(err, entity.PermissionDeniedError) {
+			msg := entity.Message{
+				Text: fmt.Sprintf("Only %v can see their subscriptions", nickname),
+			}
+			body, err := json.Marshal(msg)
+			if err != nil {
+				ctx.SetStatusCode(http.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetContentType("application/json")
+			ctx.SetStatusCode(http.StatusForbidden)
+			ctx.SetBody(body)
+			return
+		}
+
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(subscriptions)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
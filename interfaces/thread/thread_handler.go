@@ -1,31 +1,49 @@
 package thread
 
 import (
+	"bufio"
+	"errors"
 	"fmt"
 	"forum/application"
 	"forum/domain/entity"
+	"forum/interfaces/common"
 	json "github.com/mailru/easyjson"
 	"github.com/valyala/fasthttp"
 	"net/http"
 	"strconv"
+	"time"
 )
 
+const streamHeartbeatInterval = 15 * time.Second
+const streamRetryMilliseconds = 3000
+
 type ThreadInfo struct {
 	ThreadApp application.ThreadAppInterface
 	userApp   application.UserAppInterface
+	streamApp application.StreamAppInterface
+	ignoreApp application.IgnoreAppInterface
 }
 
 func NewThreadInfo(
 	ThreadApp application.ThreadAppInterface,
 	userApp application.UserAppInterface,
+	streamApp application.StreamAppInterface,
+	ignoreApp application.IgnoreAppInterface,
 ) *ThreadInfo {
 	return &ThreadInfo{
 		ThreadApp: ThreadApp,
 		userApp:   userApp,
+		streamApp: streamApp,
+		ignoreApp: ignoreApp,
 	}
 }
 
 func (threadInfo *ThreadInfo) HandleCreateThread(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
 	forumnameInterface := ctx.UserValue("threadnameOrID")
 	var slug string
 	switch forumnameInterface.(type) {
@@ -73,29 +91,34 @@ func (threadInfo *ThreadInfo) HandleCreateThread(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	for _, post := range posts {
-		_, err = threadInfo.userApp.CheckIfUserExists(post.Author)
-		if err != nil {
-			msg := entity.Message{
-				Text: fmt.Sprintf("Can't find post author by nickname: %v", post.Author),
-			}
-			body, err := json.Marshal(msg)
-			if err != nil {
-				ctx.SetStatusCode(http.StatusInternalServerError)
-				return
-			}
-
-			ctx.SetContentType("application/json")
-			ctx.SetStatusCode(http.StatusNotFound)
-			ctx.SetBody(body)
-			return
-		}
+	for i := range posts {
+		posts[i].Author = sessionUser.Nickname
 	}
 
 	err = threadInfo.ThreadApp.CreatePosts(thread, posts)
 	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.ThreadLockedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("Thread %v is locked, new posts are not accepted", slug)
+		case errors.Is(err, entity.ThreadArchivedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("Thread %v is archived and read-only", slug)
+		case errors.Is(err, entity.UserBannedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("User %v is banned", sessionUser.Nickname)
+		case errors.Is(err, entity.UserMutedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("User %v is muted in forum %v", sessionUser.Nickname, thread.Forum)
+		default:
+			status = http.StatusConflict
+			text = fmt.Sprintf("Parent post was created in another thread")
+		}
+
 		msg := entity.Message{
-			Text: fmt.Sprintf("Parent post was created in another thread"),
+			Text: text,
 		}
 		body, err := json.Marshal(msg)
 		if err != nil {
@@ -104,7 +127,7 @@ func (threadInfo *ThreadInfo) HandleCreateThread(ctx *fasthttp.RequestCtx) {
 		}
 
 		ctx.SetContentType("application/json")
-		ctx.SetStatusCode(http.StatusConflict)
+		ctx.SetStatusCode(status)
 		ctx.SetBody(body)
 		return
 	}
@@ -162,6 +185,11 @@ func (threadInfo *ThreadInfo) HandleGetThreadDetails(ctx *fasthttp.RequestCtx) {
 }
 
 func (threadInfo *ThreadInfo) HandleUpdateThread(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
 	forumnameInterface := ctx.UserValue("threadnameOrID")
 	var slug string
 	switch forumnameInterface.(type) {
@@ -196,15 +224,46 @@ func (threadInfo *ThreadInfo) HandleUpdateThread(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	if thread.Title == "" && thread.Message == "" {
+	if thread.Title == "" && thread.Message == "" && thread.Tags == nil {
 		thread, err = threadInfo.ThreadApp.GetThread(slug)
 		if err != nil {
 			ctx.SetStatusCode(http.StatusBadRequest)
 			return
 		}
 	} else {
-		err = threadInfo.ThreadApp.UpdateThread(slug, thread)
+		err = threadInfo.ThreadApp.UpdateThread(slug, thread, sessionUser.Nickname)
 		if err != nil {
+			if common.WriteThreadInputError(ctx, err, thread.Forum) {
+				return
+			}
+
+			if errors.Is(err, entity.PermissionDeniedError) || errors.Is(err, entity.ThreadArchivedError) ||
+				errors.Is(err, entity.UserBannedError) || errors.Is(err, entity.UserMutedError) {
+				text := fmt.Sprintf("Thread %v can only be edited by its author or a moderator", slug)
+				switch {
+				case errors.Is(err, entity.ThreadArchivedError):
+					text = fmt.Sprintf("Thread %v is archived and read-only", slug)
+				case errors.Is(err, entity.UserBannedError):
+					text = fmt.Sprintf("User %v is banned", sessionUser.Nickname)
+				case errors.Is(err, entity.UserMutedError):
+					text = fmt.Sprintf("User %v is muted in the forum of thread %v", sessionUser.Nickname, slug)
+				}
+
+				msg := entity.Message{
+					Text: text,
+				}
+				body, err := json.Marshal(msg)
+				if err != nil {
+					ctx.SetStatusCode(http.StatusInternalServerError)
+					return
+				}
+
+				ctx.SetContentType("application/json")
+				ctx.SetStatusCode(http.StatusForbidden)
+				ctx.SetBody(body)
+				return
+			}
+
 			ctx.SetStatusCode(http.StatusInternalServerError)
 			return
 		}
@@ -283,6 +342,21 @@ func (threadInfo *ThreadInfo) HandleGetThreadPosts(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
+	sessionUser, ok := ctx.UserValue(string(entity.CookieInfoKey)).(*entity.User)
+	if ok {
+		err = threadInfo.ThreadApp.MarkPostsRead(sessionUser.Nickname, posts, sort, desc)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		err = threadInfo.ignoreApp.CollapseIgnoredPosts(posts, sessionUser.Nickname)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+	}
+
 	body, err := json.Marshal(entity.Posts(posts))
 	if err != nil {
 		ctx.SetStatusCode(http.StatusInternalServerError)
@@ -296,6 +370,11 @@ func (threadInfo *ThreadInfo) HandleGetThreadPosts(ctx *fasthttp.RequestCtx) {
 }
 
 func (threadInfo *ThreadInfo) HandleVoteForThread(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
 	forumnameInterface := ctx.UserValue("threadnameOrID")
 	var slug string
 	switch forumnameInterface.(type) {
@@ -313,6 +392,7 @@ func (threadInfo *ThreadInfo) HandleVoteForThread(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
+	vote.Nickname = sessionUser.Nickname
 	vote.Slug = slug
 	id, err := strconv.Atoi(slug)
 	if err != nil {
@@ -321,6 +401,69 @@ func (threadInfo *ThreadInfo) HandleVoteForThread(ctx *fasthttp.RequestCtx) {
 	vote.ID = id
 
 	thread, err := threadInfo.ThreadApp.VoteForThread(vote)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.InvalidVoteError):
+			status = http.StatusBadRequest
+			text = "Voice must be -1, 0 or 1"
+		case errors.Is(err, entity.ThreadArchivedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("Thread %v is archived, votes are not accepted", slug)
+		case errors.Is(err, entity.UserBannedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("User %v is banned", sessionUser.Nickname)
+		case errors.Is(err, entity.UserMutedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("User %v is muted in the forum of thread %v", sessionUser.Nickname, slug)
+		default:
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find thread by slug: %v", slug)
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(thread)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+	return
+}
+
+// HandleStreamThreadPosts pushes new thread posts as server-sent events.
+// Last-Event-ID header (or since query parameter) resumes after the given post id,
+// otherwise only posts created after connecting are sent.
+func (threadInfo *ThreadInfo) HandleStreamThreadPosts(ctx *fasthttp.RequestCtx) {
+	forumnameInterface := ctx.UserValue("threadnameOrID")
+	var slug string
+	switch forumnameInterface.(type) {
+	case string:
+		slug = forumnameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	thread, err := threadInfo.ThreadApp.GetThreadForumAndID(slug)
 	if err != nil {
 		msg := entity.Message{
 			Text: fmt.Sprintf("Can't find thread by slug: %v", slug),
@@ -337,6 +480,599 @@ func (threadInfo *ThreadInfo) HandleVoteForThread(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
+	sinceParam := string(ctx.Request.Header.Peek("Last-Event-ID"))
+	if sinceParam == "" {
+		sinceParam = string(ctx.QueryArgs().Peek(string(entity.SinceKey)))
+	}
+
+	var since int
+	if sinceParam != "" {
+		since, err = strconv.Atoi(sinceParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	} else {
+		since, err = threadInfo.streamApp.GetLastPostID(thread.ID)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	var viewer string
+	sessionUser, ok := ctx.UserValue(string(entity.CookieInfoKey)).(*entity.User)
+	if ok {
+		viewer = sessionUser.Nickname
+	}
+
+	ctx.SetContentType("text/event-stream")
+	ctx.Response.Header.Set("Cache-Control", "no-cache")
+	ctx.Response.Header.Set("X-Accel-Buffering", "no")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBodyStreamWriter(func(w *bufio.Writer) {
+		threadInfo.streamThreadPosts(w, thread.ID, since, viewer)
+	})
+}
+
+// streamThreadPosts writes events until the client goes away, which is noticed on the next flush.
+// Posts of users the viewer ignores are sent collapsed, as in the thread listing
+func (threadInfo *ThreadInfo) streamThreadPosts(w *bufio.Writer, threadID int, since int, viewer string) {
+	wake, unsubscribe := threadInfo.streamApp.SubscribeThreadPosts(threadID)
+	defer unsubscribe()
+
+	heartbeat := time.NewTicker(streamHeartbeatInterval)
+	defer heartbeat.Stop()
+
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetryMilliseconds)
+	if w.Flush() != nil {
+		return
+	}
+
+	// posts committed before subscribing are caught up by the first fetch
+	lastID := since
+	for {
+		for {
+			posts, err := threadInfo.streamApp.GetPostsSince(threadID, lastID)
+			if err != nil {
+				return
+			}
+			if len(posts) == 0 {
+				break
+			}
+			if viewer != "" {
+				err = threadInfo.ignoreApp.CollapseIgnoredPosts(posts, viewer)
+				if err != nil {
+					return
+				}
+			}
+
+			for _, post := range posts {
+				body, err := json.Marshal(post)
+				if err != nil {
+					return
+				}
+				fmt.Fprintf(w, "id: %d\nevent: post\ndata: %s\n\n", post.ID, body)
+				lastID = post.ID
+			}
+
+			if w.Flush() != nil {
+				return
+			}
+		}
+
+		select {
+		case <-wake:
+		case <-heartbeat.C:
+			w.WriteString(": heartbeat\n\n")
+			if w.Flush() != nil {
+				return
+			}
+		}
+	}
+}
+
+func (threadInfo *ThreadInfo) HandleDeleteThread(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	forumnameInterface := ctx.UserValue("threadnameOrID")
+	var slug string
+	switch forumnameInterface.(type) {
+	case string:
+		slug = forumnameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	err := threadInfo.ThreadApp.DeleteThread(slug, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.PermissionDeniedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("Only moderators can delete thread %v", slug)
+		case errors.Is(err, entity.ThreadNotFoundError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find thread by slug: %v", slug)
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	ctx.SetStatusCode(http.StatusNoContent)
+}
+
+func (threadInfo *ThreadInfo) HandleSetThreadState(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	forumnameInterface := ctx.UserValue("threadnameOrID")
+	var slug string
+	switch forumnameInterface.(type) {
+	case string:
+		slug = forumnameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	state := &entity.ThreadStateInput{}
+	err := json.Unmarshal(ctx.Request.Body(), state)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	thread, err := threadInfo.ThreadApp.SetThreadState(slug, state, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.PermissionDeniedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("Only moderators can change the state of thread %v", slug)
+		case errors.Is(err, entity.ThreadNotFoundError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find thread by slug: %v", slug)
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(thread)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (threadInfo *ThreadInfo) HandleRetractVote(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	threadnameInterface := ctx.UserValue("threadnameOrID")
+	var slug string
+	switch threadnameInterface.(type) {
+	case string:
+		slug = threadnameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	thread, err := threadInfo.ThreadApp.RetractVote(slug, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.ThreadArchivedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("Thread %v is archived, votes can't be changed", slug)
+		default:
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find thread by slug: %v", slug)
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(thread)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (threadInfo *ThreadInfo) HandleGetThreadVotes(ctx *fasthttp.RequestCtx) {
+	threadnameInterface := ctx.UserValue("threadnameOrID")
+	var slug string
+	switch threadnameInterface.(type) {
+	case string:
+		slug = threadnameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	queryParams := ctx.QueryArgs()
+
+	limitParam := string(queryParams.Peek(string(entity.LimitKey)))
+	limit := 0
+	if limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	descParam := string(queryParams.Peek(string(entity.DescKey)))
+	desc := descParam == "true"
+
+	sinceParam := string(queryParams.Peek(string(entity.SinceKey)))
+	since := sinceParam
+
+	votes, err := threadInfo.ThreadApp.GetThreadVotes(slug, int32(limit), since, desc)
+	if err != nil {
+		msg := entity.Message{
+			Text: fmt.Sprintf("Can't find thread by slug: %v", slug),
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(http.StatusNotFound)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(entity.ThreadVotes(votes))
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+// HandleGetMyVotes lists the thread votes of the logged-in user, since is a thread id
+func (threadInfo *ThreadInfo) HandleGetMyVotes(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	queryParams := ctx.QueryArgs()
+
+	limitParam := string(queryParams.Peek(string(entity.LimitKey)))
+	limit := 0
+	if limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	sinceParam := string(queryParams.Peek(string(entity.SinceKey)))
+	since := 0
+	if sinceParam != "" {
+		var err error
+		since, err = strconv.Atoi(sinceParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	descParam := string(queryParams.Peek(string(entity.DescKey)))
+	desc := descParam == "true"
+
+	votes, err := threadInfo.ThreadApp.GetUserVotes(sessionUser.Nickname, int32(limit), since, desc)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(entity.ThreadVotes(votes))
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (threadInfo *ThreadInfo) HandleSetReadMarker(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	threadnameInterface := ctx.UserValue("threadnameOrID")
+	var slug string
+	switch threadnameInterface.(type) {
+	case string:
+		slug = threadnameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	input := &entity.ReadMarkerInput{}
+	if len(ctx.Request.Body()) != 0 {
+		err := json.Unmarshal(ctx.Request.Body(), input)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	thread, err := threadInfo.ThreadApp.SetReadMarker(slug, input.Post, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.PostNotFoundError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find post with id %v in thread %v", input.Post, slug)
+		default:
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find thread by slug: %v", slug)
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(thread)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+// HandleGetTagThreads pages like the forum threads listing, since is a timestamp
+func (threadInfo *ThreadInfo) HandleGetTagThreads(ctx *fasthttp.RequestCtx) {
+	tagInterface := ctx.UserValue("tag")
+
+	var tag string
+	switch tagInterface.(type) {
+	case string:
+		tag = tagInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	queryParams := ctx.QueryArgs()
+
+	limitParam := string(queryParams.Peek(string(entity.LimitKey)))
+	limit, err := strconv.Atoi(limitParam)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	descParam := string(queryParams.Peek(string(entity.DescKey)))
+	desc := descParam == "true"
+
+	sinceParam := string(queryParams.Peek(string(entity.SinceKey)))
+	since := sinceParam
+
+	threads, err := threadInfo.ThreadApp.GetThreadsByTag(tag, int32(limit), since, desc)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	sessionUser, ok := ctx.UserValue(string(entity.CookieInfoKey)).(*entity.User)
+	if ok {
+		err = threadInfo.ThreadApp.AddUnreadCounts(threads, sessionUser.Nickname)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+	}
+
+	body, err := json.Marshal(entity.Threads(threads))
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (threadInfo *ThreadInfo) HandleAutocompleteTags(ctx *fasthttp.RequestCtx) {
+	queryParams := ctx.QueryArgs()
+
+	limitParam := string(queryParams.Peek(string(entity.LimitKey)))
+	limit := 0
+	if limitParam != "" {
+		var err error
+		limit, err = strconv.Atoi(limitParam)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusBadRequest)
+			return
+		}
+	}
+
+	prefixParam := string(queryParams.Peek(string(entity.PrefixKey)))
+	prefix := prefixParam
+
+	tags, err := threadInfo.ThreadApp.AutocompleteTags(prefix, int32(limit))
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(entity.TagCounts(tags))
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (threadInfo *ThreadInfo) HandleMoveThread(ctx *fasthttp.RequestCtx) {
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	forumnameInterface := ctx.UserValue("threadnameOrID")
+	var slug string
+	switch forumnameInterface.(type) {
+	case string:
+		slug = forumnameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	input := &entity.ThreadMoveInput{}
+	err := json.Unmarshal(ctx.Request.Body(), input)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	thread, err := threadInfo.ThreadApp.MoveThread(slug, input, sessionUser.Nickname)
+	if err != nil {
+		if common.WriteThreadInputError(ctx, err, input.Forum) {
+			return
+		}
+
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.PermissionDeniedError):
+			status = http.StatusForbidden
+			text = fmt.Sprintf("Only moderators of both forums can move thread %v", slug)
+		case errors.Is(err, entity.ForumNotExistError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find forum by slug: %v", input.Forum)
+		case errors.Is(err, entity.DataError):
+			status = http.StatusBadRequest
+			text = fmt.Sprintf("Thread %v is already in forum %v", slug, input.Forum)
+		case errors.Is(err, entity.ThreadIsRedirectError):
+			status = http.StatusBadRequest
+			text = fmt.Sprintf("Thread %v is a redirect stub, move the thread it points at", slug)
+		case errors.Is(err, entity.ThreadMovedError):
+			status = http.StatusConflict
+			text = err.Error()
+		case errors.Is(err, entity.ThreadNotFoundError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find thread by slug: %v", slug)
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
 	body, err := json.Marshal(thread)
 	if err != nil {
 		ctx.SetStatusCode(http.StatusInternalServerError)
@@ -346,5 +1082,4 @@ func (threadInfo *ThreadInfo) HandleVoteForThread(ctx *fasthttp.RequestCtx) {
 	ctx.SetContentType("application/json")
 	ctx.SetStatusCode(http.StatusOK)
 	ctx.SetBody(body)
-	return
 }
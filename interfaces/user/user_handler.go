@@ -5,18 +5,24 @@ import (
 	"fmt"
 	"forum/application"
 	"forum/domain/entity"
+	"forum/interfaces/common"
 	json "github.com/mailru/easyjson"
 	"github.com/valyala/fasthttp"
+	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
 )
 
 type UserInfo struct {
-	userApp application.UserAppInterface
+	userApp   application.UserAppInterface
+	avatarApp application.AvatarAppInterface
 }
 
-func NewUserInfo(userApp application.UserAppInterface) *UserInfo {
+func NewUserInfo(userApp application.UserAppInterface, avatarApp application.AvatarAppInterface) *UserInfo {
 	return &UserInfo{
-		userApp: userApp,
+		userApp:   userApp,
+		avatarApp: avatarApp,
 	}
 }
 
@@ -38,7 +44,7 @@ func (userInfo *UserInfo) HandleCreateUser(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
-	err = userInfo.userApp.CreateUser(userInput)
+	setupToken, err := userInfo.userApp.CreateUser(userInput)
 	if err != nil {
 		users, err := userInfo.userApp.GetUsersWithNicknameAndEmail(userInput.Nickname, userInput.Email)
 		if err != nil {
@@ -64,6 +70,8 @@ func (userInfo *UserInfo) HandleCreateUser(ctx *fasthttp.RequestCtx) {
 		return
 	}
 
+	// the token is only shown here, POST /user/{username}/password spends it on the first password
+	ctx.Response.Header.Set(entity.PasswordSetupTokenHeader, setupToken.Token)
 	ctx.SetContentType("application/json")
 	ctx.SetStatusCode(http.StatusCreated)
 	ctx.SetBody(body)
@@ -178,3 +186,284 @@ func (userInfo *UserInfo) HandleUpdateUser(ctx *fasthttp.RequestCtx) {
 	ctx.SetStatusCode(http.StatusOK)
 	ctx.SetBody(body)
 }
+
+func (userInfo *UserInfo) HandleSetPassword(ctx *fasthttp.RequestCtx) {
+	userInfo.handlePassword(ctx, false)
+}
+
+func (userInfo *UserInfo) HandleChangePassword(ctx *fasthttp.RequestCtx) {
+	userInfo.handlePassword(ctx, true)
+}
+
+// handlePassword replaces the password of the logged-in user named in the path, or sets the first one
+// of that user with a setup token, which needs no session since the account can not log in yet
+func (userInfo *UserInfo) handlePassword(ctx *fasthttp.RequestCtx, change bool) {
+	usernameInterface := ctx.UserValue("username")
+	var nickname string
+
+	switch usernameInterface.(type) {
+	case string:
+		nickname = usernameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	if change {
+		sessionUser, ok := common.RequireUser(ctx)
+		if !ok {
+			return
+		}
+
+		if !strings.EqualFold(sessionUser.Nickname, nickname) {
+			msg := entity.Message{
+				Text: fmt.Sprintf("Can't change password of user %v", nickname),
+			}
+			body, err := json.Marshal(msg)
+			if err != nil {
+				ctx.SetStatusCode(http.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetContentType("application/json")
+			ctx.SetStatusCode(http.StatusForbidden)
+			ctx.SetBody(body)
+			return
+		}
+		nickname = sessionUser.Nickname
+	} else {
+		var err error
+		nickname, err = userInfo.userApp.CheckIfUserExists(nickname)
+		if err != nil {
+			msg := entity.Message{
+				Text: fmt.Sprintf("Can't find user with id #%v\n", nickname),
+			}
+			body, err := json.Marshal(msg)
+			if err != nil {
+				ctx.SetStatusCode(http.StatusInternalServerError)
+				return
+			}
+
+			ctx.SetContentType("application/json")
+			ctx.SetStatusCode(http.StatusNotFound)
+			ctx.SetBody(body)
+			return
+		}
+	}
+
+	input := &entity.PasswordInput{}
+	err := json.Unmarshal(ctx.Request.Body(), input)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	if change {
+		err = userInfo.userApp.ChangePassword(nickname, input)
+	} else {
+		err = userInfo.userApp.SetPassword(nickname, input)
+	}
+
+	if err != nil {
+		var status int
+		switch {
+		case errors.Is(err, entity.WeakPasswordError):
+			status = http.StatusBadRequest
+		case errors.Is(err, entity.WrongPasswordError), errors.Is(err, entity.InvalidSetupTokenError):
+			status = http.StatusForbidden
+		case errors.Is(err, entity.PasswordAlreadySetError), errors.Is(err, entity.PasswordNotSetError):
+			status = http.StatusConflict
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: err.Error(),
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	profile, err := userInfo.userApp.GetUserByNickname(nickname)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	body, err := json.Marshal(profile)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+func (userInfo *UserInfo) HandleUploadAvatar(ctx *fasthttp.RequestCtx) {
+	usernameInterface := ctx.UserValue("username")
+	var nickname string
+
+	switch usernameInterface.(type) {
+	case string:
+		nickname = usernameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	if !strings.EqualFold(sessionUser.Nickname, nickname) {
+		msg := entity.Message{
+			Text: fmt.Sprintf("Can't change avatar of user %v", nickname),
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(http.StatusForbidden)
+		ctx.SetBody(body)
+		return
+	}
+
+	fileHeader, err := ctx.FormFile("avatar")
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+	if fileHeader.Size > application.MaxAvatarSize {
+		common.WriteMessage(ctx, http.StatusRequestEntityTooLarge, entity.AvatarTooLargeError.Error())
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	data, err := ioutil.ReadAll(io.LimitReader(file, application.MaxAvatarSize+1))
+	if err != nil {
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	profile, err := userInfo.avatarApp.UpdateAvatar(sessionUser.Nickname, data)
+	if err != nil {
+		var status int
+		switch {
+		case errors.Is(err, entity.AvatarTooLargeError):
+			status = http.StatusRequestEntityTooLarge
+		case errors.Is(err, entity.UnsupportedAvatarTypeError):
+			status = http.StatusUnsupportedMediaType
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: err.Error(),
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(profile)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusOK)
+	ctx.SetBody(body)
+}
+
+// HandleIssuePasswordSetupToken is for admins helping owners of accounts created without a password
+func (userInfo *UserInfo) HandleIssuePasswordSetupToken(ctx *fasthttp.RequestCtx) {
+	usernameInterface := ctx.UserValue("username")
+	var nickname string
+
+	switch usernameInterface.(type) {
+	case string:
+		nickname = usernameInterface.(string)
+	default:
+		ctx.SetStatusCode(http.StatusBadRequest)
+		return
+	}
+
+	sessionUser, ok := common.RequireUser(ctx)
+	if !ok {
+		return
+	}
+
+	setupToken, err := userInfo.userApp.IssuePasswordSetupToken(nickname, sessionUser.Nickname)
+	if err != nil {
+		var status int
+		var text string
+		switch {
+		case errors.Is(err, entity.PermissionDeniedError):
+			status = http.StatusForbidden
+			text = "Only admins can issue password setup tokens"
+		case errors.Is(err, entity.UserDoesntExistsError):
+			status = http.StatusNotFound
+			text = fmt.Sprintf("Can't find user with id #%v\n", nickname)
+		case errors.Is(err, entity.PasswordAlreadySetError):
+			status = http.StatusConflict
+			text = err.Error()
+		default:
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		msg := entity.Message{
+			Text: text,
+		}
+		body, err := json.Marshal(msg)
+		if err != nil {
+			ctx.SetStatusCode(http.StatusInternalServerError)
+			return
+		}
+
+		ctx.SetContentType("application/json")
+		ctx.SetStatusCode(status)
+		ctx.SetBody(body)
+		return
+	}
+
+	body, err := json.Marshal(setupToken)
+	if err != nil {
+		ctx.SetStatusCode(http.StatusInternalServerError)
+		return
+	}
+
+	ctx.SetContentType("application/json")
+	ctx.SetStatusCode(http.StatusCreated)
+	ctx.SetBody(body)
+}
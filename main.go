@@ -3,16 +3,29 @@ package main
 import (
 	"context"
 	"forum/application"
+	"forum/domain/entity"
 	"forum/infrastructure/persistence"
+	"forum/interfaces/audit"
+	"forum/interfaces/ban"
+	"forum/interfaces/conversation"
 	"forum/interfaces/forum"
+	"forum/interfaces/ignore"
+	"forum/interfaces/live"
+	"forum/interfaces/notification"
 	"forum/interfaces/post"
+	"forum/interfaces/report"
+	"forum/interfaces/search"
 	"forum/interfaces/service"
+	"forum/interfaces/session"
+	"forum/interfaces/subscription"
 	"forum/interfaces/thread"
 	"forum/interfaces/user"
 
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/fasthttp/router"
@@ -22,6 +35,9 @@ import (
 	"go.uber.org/zap"
 )
 
+// maxRequestBodySize is the largest body the server reads, an avatar upload with room for its multipart framing
+const maxRequestBodySize = application.MaxAvatarSize + 1<<20
+
 func loggerMid(req fasthttp.RequestHandler) fasthttp.RequestHandler {
 	return fasthttp.RequestHandler(func(ctx *fasthttp.RequestCtx) {
 		begin := time.Now()
@@ -35,6 +51,20 @@ func loggerMid(req fasthttp.RequestHandler) fasthttp.RequestHandler {
 	})
 }
 
+// authMid resolves session_id cookie to the logged-in user and stores it under CookieInfoKey
+func authMid(sessionApp application.SessionAppInterface, req fasthttp.RequestHandler) fasthttp.RequestHandler {
+	return fasthttp.RequestHandler(func(ctx *fasthttp.RequestCtx) {
+		sessionID := string(ctx.Request.Header.Cookie(entity.CookieNameKey))
+		if sessionID != "" {
+			user, err := sessionApp.GetUserBySession(sessionID)
+			if err == nil {
+				ctx.SetUserValue(string(entity.CookieInfoKey), user)
+			}
+		}
+		req(ctx)
+	})
+}
+
 func runServer(addr string) {
 	err := godotenv.Load(".env")
 	if err != nil {
@@ -55,18 +85,71 @@ func runServer(addr string) {
 	postRepo := persistence.NewPostRepository(postgresConn)
 	threadRepo := persistence.NewThreadRepository(postgresConn)
 	serviceRepo := persistence.NewServiceRepository(postgresConn)
+	sessionRepo := persistence.NewSessionRepository(postgresConn)
+	notificationRepo := persistence.NewNotificationRepository(postgresConn)
+	searchRepo := persistence.NewSearchRepository(postgresConn)
+	roleRepo := persistence.NewRoleRepository(postgresConn)
+	banRepo := persistence.NewBanRepository(postgresConn)
+	reportRepo := persistence.NewReportRepository(postgresConn)
+	auditRepo := persistence.NewAuditRepository(postgresConn)
+	subscriptionRepo := persistence.NewSubscriptionRepository(postgresConn)
+	conversationRepo := persistence.NewConversationRepository(postgresConn)
+	ignoreRepo := persistence.NewIgnoreRepository(postgresConn)
+	listener := persistence.NewListener(postgresConn)
+	postListener := persistence.NewPostListener(listener)
+	eventListener := persistence.NewEventListener(postgresConn, listener)
+	go listener.Listen(context.Background())
+	avatarDir := os.Getenv("AVATAR_DIR")
+	avatarStorage, err := persistence.NewLocalAvatarStorage(avatarDir)
+	if err != nil {
+		log.Fatal("Could not create avatar storage", zap.String("error", err.Error()))
+		return
+	}
 
-	serviceApp := application.NewServiceApp(serviceRepo)
-	userApp := application.NewUserApp(userRepo)
-	forumApp := application.NewForumApp(forumRepo)
-	postApp := application.NewPostApp(postRepo)
-	threadApp := application.NewThreadApp(threadRepo, forumApp)
+	passwordCost, err := strconv.Atoi(os.Getenv("PASSWORD_HASH_COST"))
+	if err != nil {
+		passwordCost = 0
+	}
+
+	var postReactions []string
+	if os.Getenv("POST_REACTIONS") != "" {
+		postReactions = strings.Split(os.Getenv("POST_REACTIONS"), ",")
+	}
 
-	forumInfo := forum.NewForumInfo(forumApp, userApp, threadApp)
-	userInfo := user.NewUserInfo(userApp)
+	auditApp := application.NewAuditApp(auditRepo, roleRepo)
+	serviceApp := application.NewServiceApp(serviceRepo, auditApp)
+	userApp := application.NewUserApp(userRepo, roleRepo, passwordCost)
+	policyApp := application.NewPolicyApp(roleRepo, banRepo, forumRepo, auditApp)
+	forumApp := application.NewForumApp(forumRepo, policyApp, auditApp)
+	threadApp := application.NewThreadApp(threadRepo, forumApp, policyApp, auditApp)
+	postApp := application.NewPostApp(postRepo, threadApp, policyApp, auditApp, postReactions)
+	sessionApp := application.NewSessionApp(sessionRepo, userApp)
+	notificationApp := application.NewNotificationApp(notificationRepo)
+	avatarApp := application.NewAvatarApp(userRepo, avatarStorage)
+	streamApp := application.NewStreamApp(threadRepo, postListener)
+	liveApp := application.NewLiveApp(eventListener)
+	searchApp := application.NewSearchApp(searchRepo)
+	banApp := application.NewBanApp(banRepo, policyApp, auditApp)
+	reportApp := application.NewReportApp(reportRepo, postApp, threadApp, policyApp, auditApp)
+	subscriptionApp := application.NewSubscriptionApp(subscriptionRepo, threadApp, forumApp)
+	conversationApp := application.NewConversationApp(conversationRepo, userApp)
+	ignoreApp := application.NewIgnoreApp(ignoreRepo, userApp)
+
+	forumInfo := forum.NewForumInfo(forumApp, userApp, threadApp, policyApp)
+	userInfo := user.NewUserInfo(userApp, avatarApp)
 	serviceInfo := service.NewServiceInfo(serviceApp)
-	postsInfo := post.NewPostInfo(postApp, userApp, threadApp, forumApp)
-	threadsInfo := thread.NewThreadInfo(threadApp, userApp)
+	postsInfo := post.NewPostInfo(postApp, userApp, threadApp, forumApp, ignoreApp)
+	threadsInfo := thread.NewThreadInfo(threadApp, userApp, streamApp, ignoreApp)
+	sessionInfo := session.NewSessionInfo(sessionApp, userApp)
+	notificationInfo := notification.NewNotificationInfo(notificationApp)
+	liveInfo := live.NewLiveInfo(liveApp)
+	searchInfo := search.NewSearchInfo(searchApp)
+	banInfo := ban.NewBanInfo(banApp)
+	reportInfo := report.NewReportInfo(reportApp)
+	auditInfo := audit.NewAuditInfo(auditApp)
+	subscriptionInfo := subscription.NewSubscriptionInfo(subscriptionApp)
+	conversationInfo := conversation.NewConversationInfo(conversationApp)
+	ignoreInfo := ignore.NewIgnoreInfo(ignoreApp)
 
 	router := router.New()
 
@@ -74,27 +157,102 @@ func runServer(addr string) {
 	router.POST(prefix+"/user/{username}/create", userInfo.HandleCreateUser)
 	router.GET(prefix+"/user/{username}/profile", userInfo.HandleGetUser)
 	router.POST(prefix+"/user/{username}/profile", userInfo.HandleUpdateUser)
+	router.POST(prefix+"/user/{username}/password", userInfo.HandleSetPassword)
+	router.POST(prefix+"/user/{username}/password/change", userInfo.HandleChangePassword)
+	router.POST(prefix+"/user/{username}/password/token", userInfo.HandleIssuePasswordSetupToken)
+	router.POST(prefix+"/user/{username}/avatar", userInfo.HandleUploadAvatar)
+	router.GET(prefix+"/user/{username}/feed", subscriptionInfo.HandleGetFeed)
+	router.GET(prefix+"/user/{username}/subscriptions", subscriptionInfo.HandleGetSubscriptions)
+
+	router.POST(prefix+"/session", sessionInfo.HandleLogin)
+	router.GET(prefix+"/session", sessionInfo.HandleGetSession)
+	router.DELETE(prefix+"/session", sessionInfo.HandleLogout)
 
 	router.POST(prefix+"/forum/create", forumInfo.HandleCreateForum)
+	router.GET(prefix+"/forum/tree", forumInfo.HandleGetForumTree)
 	router.GET(prefix+"/forum/{forumname}/details", forumInfo.HandleGetForumDetails)
 	router.GET(prefix+"/forum/{forumname}/users", forumInfo.HandleGetForumUsers)
 	router.GET(prefix+"/forum/{forumname}/threads", forumInfo.HandleGetForumThreads)
+	router.GET(prefix+"/forum/{forumname}/tags", forumInfo.HandleGetForumTags)
 	router.POST(prefix+"/forum/{forumname}/create", forumInfo.HandleCreateForumThread)
+	router.DELETE(prefix+"/forum/{forumname}", forumInfo.HandleDeleteForum)
+	router.GET(prefix+"/forum/{forumname}/moderators", forumInfo.HandleGetForumModerators)
+	router.GET(prefix+"/forum/{forumname}/moderation", reportInfo.HandleGetReportQueue)
+	router.POST(prefix+"/forum/{forumname}/moderation/resolve", reportInfo.HandleResolveReports)
+	router.PUT(prefix+"/forum/{forumname}/moderators/{nickname}", forumInfo.HandleGrantModerator)
+	router.DELETE(prefix+"/forum/{forumname}/moderators/{nickname}", forumInfo.HandleRevokeModerator)
+	router.POST(prefix+"/forum/{forumname}/subscribe", subscriptionInfo.HandleSubscribeForum)
+	router.DELETE(prefix+"/forum/{forumname}/subscribe", subscriptionInfo.HandleUnsubscribeForum)
 
 	router.GET(prefix+"/thread/{threadnameOrID}/details", threadsInfo.HandleGetThreadDetails)
 	router.POST(prefix+"/thread/{threadnameOrID}/details", threadsInfo.HandleUpdateThread)
 	router.GET(prefix+"/thread/{threadnameOrID}/posts", threadsInfo.HandleGetThreadPosts)
+	router.POST(prefix+"/thread/{threadnameOrID}/read", threadsInfo.HandleSetReadMarker)
 	router.POST(prefix+"/thread/{threadnameOrID}/vote", threadsInfo.HandleVoteForThread)
+	router.DELETE(prefix+"/thread/{threadnameOrID}/vote", threadsInfo.HandleRetractVote)
+	router.GET(prefix+"/thread/{threadnameOrID}/votes", threadsInfo.HandleGetThreadVotes)
 	router.POST(prefix+"/thread/{threadnameOrID}/create", threadsInfo.HandleCreateThread)
+	router.GET(prefix+"/thread/{threadnameOrID}/stream", threadsInfo.HandleStreamThreadPosts)
+	router.DELETE(prefix+"/thread/{threadnameOrID}", threadsInfo.HandleDeleteThread)
+	router.POST(prefix+"/thread/{threadnameOrID}/state", threadsInfo.HandleSetThreadState)
+	router.POST(prefix+"/thread/{threadnameOrID}/move", threadsInfo.HandleMoveThread)
+	router.POST(prefix+"/thread/{threadnameOrID}/report", reportInfo.HandleReportThread)
+	router.POST(prefix+"/thread/{threadnameOrID}/subscribe", subscriptionInfo.HandleSubscribeThread)
+	router.DELETE(prefix+"/thread/{threadnameOrID}/subscribe", subscriptionInfo.HandleUnsubscribeThread)
+
+	router.GET(prefix+"/tag/{tag}/threads", threadsInfo.HandleGetTagThreads)
+	router.GET(prefix+"/tags", threadsInfo.HandleAutocompleteTags)
 
 	router.GET(prefix+"/post/{postID}/details", postsInfo.HandleGetPostDetails)
 	router.POST(prefix+"/post/{postID}/details", postsInfo.HandleChangePost)
+	router.DELETE(prefix+"/post/{postID}", postsInfo.HandleDeletePost)
+	router.POST(prefix+"/post/{postID}/restore", postsInfo.HandleRestorePost)
+	router.GET(prefix+"/post/{postID}/history", postsInfo.HandleGetPostHistory)
+	router.POST(prefix+"/post/{postID}/report", reportInfo.HandleReportPost)
+	router.POST(prefix+"/post/{postID}/reactions", postsInfo.HandleAddPostReaction)
+	router.DELETE(prefix+"/post/{postID}/reactions", postsInfo.HandleRemovePostReaction)
+	router.POST(prefix+"/post/{postID}/vote", postsInfo.HandleVoteForPost)
+
+	router.GET(prefix+"/notifications", notificationInfo.HandleGetNotifications)
+	router.GET(prefix+"/notifications/{notificationID}", notificationInfo.HandleGetNotification)
+	router.POST(prefix+"/notifications/read", notificationInfo.HandleMarkNotificationsRead)
+
+	router.GET(prefix+"/votes", threadsInfo.HandleGetMyVotes)
+
+	router.GET(prefix+"/conversations", conversationInfo.HandleGetConversations)
+	router.POST(prefix+"/conversations", conversationInfo.HandleStartConversation)
+	router.GET(prefix+"/conversations/{conversationID}/messages", conversationInfo.HandleGetMessages)
+	router.POST(prefix+"/conversations/{conversationID}/messages", conversationInfo.HandleSendMessage)
+
+	router.GET(prefix+"/blocks", conversationInfo.HandleGetBlockedUsers)
+	router.PUT(prefix+"/blocks/{nickname}", conversationInfo.HandleBlockUser)
+	router.DELETE(prefix+"/blocks/{nickname}", conversationInfo.HandleUnblockUser)
+
+	router.GET(prefix+"/ignores", ignoreInfo.HandleGetIgnoredUsers)
+	router.PUT(prefix+"/ignores/{nickname}", ignoreInfo.HandleIgnoreUser)
+	router.DELETE(prefix+"/ignores/{nickname}", ignoreInfo.HandleUnignoreUser)
+
+	router.GET(prefix+"/live", liveInfo.HandleLive)
+	router.GET(prefix+"/search", searchInfo.HandleSearch)
+
+	router.GET(prefix+"/bans", banInfo.HandleGetBans)
+	router.POST(prefix+"/bans", banInfo.HandleCreateBan)
+	router.DELETE(prefix+"/bans/{banID}", banInfo.HandleLiftBan)
+
+	router.GET(prefix+"/admin/audit", auditInfo.HandleGetAuditLog)
 
 	router.GET(prefix+"/service/status", serviceInfo.HandleGetDBStatus)
 	router.POST(prefix+"/service/clear", serviceInfo.HandleClearData)
 
+	router.ServeFilesCustom("/assets/{filepath:*}", &fasthttp.FS{Root: "assets"})
+	router.ServeFilesCustom("/"+entity.AvatarPublicPath+"/{filepath:*}", &fasthttp.FS{Root: avatarDir})
+
 	fmt.Printf("Starting server at localhost%s\n", addr)
-	fasthttp.ListenAndServe(addr, loggerMid(router.Handler))
+	server := &fasthttp.Server{
+		Handler:            loggerMid(authMid(sessionApp, router.Handler)),
+		MaxRequestBodySize: maxRequestBodySize,
+	}
+	server.ListenAndServe(addr)
 }
 
 func main() {